@@ -0,0 +1,39 @@
+// Command dictlint checks word dictionaries for duplicates and inconsistencies.
+//
+// Usage:
+//
+//	dictlint              # the built-in dictionaries
+//	dictlint -dir ./dict  # the files of a DICTIONARY_DIR
+//
+// It prints one line per issue and exits with status 1 if any was found.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"impostor/internal/game"
+	"os"
+)
+
+func main() {
+	dir := flag.String("dir", "", "directory of dictionary files (default: the built-in dictionaries)")
+	flag.Parse()
+
+	d := game.CurrentDictionary()
+	if *dir != "" {
+		var err error
+		if d, err = game.ReadDictionaryDir(*dir); err != nil {
+			fmt.Fprintf(os.Stderr, "dictlint: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	issues := d.Lint()
+	for _, issue := range issues {
+		fmt.Println(issue)
+	}
+	if len(issues) > 0 {
+		fmt.Fprintf(os.Stderr, "dictlint: %d issue(s)\n", len(issues))
+		os.Exit(1)
+	}
+}
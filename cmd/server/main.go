@@ -1,10 +1,24 @@
 package main
 
 import (
+	"context"
 	"impostor/internal/platform/server"
+	"log"
+	"os/signal"
+	"syscall"
 )
 
 func main() {
 	srv := server.NewServer()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		if err := srv.Shutdown(); err != nil {
+			log.Printf("Error shutting down: %v", err)
+		}
+	}()
+
 	srv.Run(":8080")
 }
@@ -0,0 +1,103 @@
+// Command wnimport suggests word pairs from a WordNet lexicon, to grow the dictionaries.
+//
+// Pairs are sibling hyponyms, two kinds of the same thing, grouped into one category per hypernym.
+// The output is a dictionary file (see DICTIONARY_DIR) meant to be reviewed and merged by hand:
+// every pair is tagged "wordnet" and has no difficulty yet.
+//
+// Usage:
+//
+//	wnimport -data dict/data.noun -hypernyms bird,tree,fruit > en.json
+//	wnimport -data dict/data.noun -omw wn-data-spa.tab -lang es -hypernyms bird,tree -o es.json
+//
+// The Spanish words come from the Open Multilingual Wordnet, whose synsets are those of WordNet 3.0,
+// so its data.noun is needed too. IDs come from the English words, which links the translations.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"impostor/internal/game"
+	"impostor/internal/game/wordnet"
+	"os"
+	"strings"
+)
+
+func main() {
+	data := flag.String("data", "", "WordNet 3.0 noun data file (data.noun)")
+	omw := flag.String("omw", "", "Open Multilingual Wordnet tab file for another language (wn-data-spa.tab)")
+	lang := flag.String("lang", game.DefaultLanguage, "language code of the output")
+	hypernyms := flag.String("hypernyms", "", "comma-separated categories to import, by word or offset (default: all)")
+	minSiblings := flag.Int("min-siblings", 3, "hyponyms a category needs to be kept")
+	maxPairs := flag.Int("max-pairs", 20, "pairs per category, 0 for no limit")
+	phrases := flag.Bool("phrases", false, "keep words of several words")
+	out := flag.String("o", "", "output file (default: stdout)")
+	flag.Parse()
+
+	if *data == "" {
+		fail("-data is required")
+	}
+	if *omw != "" && *lang == game.DefaultLanguage {
+		fail("-omw needs the -lang of its words")
+	}
+
+	lexicon, err := readLexicon(*data, *omw)
+	if err != nil {
+		fail(err.Error())
+	}
+
+	opts := wordnet.Options{MinSiblings: *minSiblings, MaxPairs: *maxPairs, Phrases: *phrases}
+	if *hypernyms != "" {
+		opts.Hypernyms = strings.Split(*hypernyms, ",")
+	}
+	categories := lexicon.Categories(opts)
+
+	encoded, err := game.MarshalDictionary(*lang, categories, nil)
+	if err != nil {
+		fail(err.Error())
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(encoded)
+	} else {
+		err = os.WriteFile(*out, encoded, 0o644)
+	}
+	if err != nil {
+		fail(err.Error())
+	}
+
+	pairs := 0
+	for _, c := range categories {
+		pairs += len(c.Pairs)
+	}
+	fmt.Fprintf(os.Stderr, "wnimport: %d categories, %d pairs to review\n", len(categories), pairs)
+}
+
+func readLexicon(data, omw string) (*wordnet.Lexicon, error) {
+	f, err := os.Open(data)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	lexicon, err := wordnet.ReadData(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", data, err)
+	}
+	if omw == "" {
+		return lexicon, nil
+	}
+
+	t, err := os.Open(omw)
+	if err != nil {
+		return nil, err
+	}
+	defer t.Close()
+	translated, err := lexicon.ReadOMW(t)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", omw, err)
+	}
+	return translated, nil
+}
+
+func fail(msg string) {
+	fmt.Fprintf(os.Stderr, "wnimport: %s\n", msg)
+	os.Exit(2)
+}
@@ -12,8 +12,9 @@ const (
 type GameMode string
 
 const (
-	ModeHard GameMode = "HARD" // Impostor sees "YOU ARE THE IMPOSTOR"
-	ModeEasy GameMode = "EASY" // Impostor sees a trap word
+	ModeHard     GameMode = "HARD"      // Impostor sees "YOU ARE THE IMPOSTOR"
+	ModeEasy     GameMode = "EASY"      // Impostor sees a trap word
+	ModeEasyHint GameMode = "EASY_HINT" // Impostor sees the category and a vague hint
 )
 
 // Player represents a connected user.
@@ -23,35 +24,79 @@ type Player struct {
 	IsLeader bool   `json:"is_leader"`
 	Role     Role   `json:"role,omitempty"` // Omitted if not revealed
 	IsAlive  bool   `json:"is_alive"`
+	Language string `json:"language,omitempty"` // Chosen at join; empty means the lobby language
 }
 
 // Card contains the information displayed to each player.
 type Card struct {
 	DisplayedWord string `json:"displayed_word"`
 	IsImpostor    bool   `json:"is_impostor"`
+	Category      string `json:"category,omitempty"` // Only for the impostor in Easy hint mode
+	Hint          string `json:"hint,omitempty"`     // Only for the impostor in Easy hint mode, empty if the pair has none
 	// In Easy mode, if IsImpostor is true, DisplayedWord is the trap word.
-	// In Hard mode, if IsImpostor is true, DisplayedWord is "YOU ARE THE IMPOSTOR".
+	// In Hard and Easy hint mode, if IsImpostor is true, DisplayedWord is "YOU ARE THE IMPOSTOR".
 }
 
 // Category groups words by theme.
+// The ID is stable and shared by the translations of a category ("animals" for "Animals" and "Animales").
 type Category struct {
-	Name string    `json:"name"`
-	Pairs []WordPair `json:"pairs"`
+	ID    string     `json:"id" yaml:"id"`
+	Name  string     `json:"name" yaml:"name"`
+	Pairs []WordPair `json:"pairs" yaml:"pairs"`
+}
+
+// InfiniteTheme is a topic of the ✨ Infinite category, played as its own "✨ Infinite: <name>" category.
+// Its pairs are generated from the seed words, with the topics keeping the traps on theme.
+// Like categories, the ID is shared by the translations of a theme.
+type InfiniteTheme struct {
+	ID     string   `json:"id" yaml:"id"`
+	Name   string   `json:"name" yaml:"name"`
+	Topics []string `json:"topics,omitempty" yaml:"topics,omitempty"`
+	Seeds  []string `json:"seeds" yaml:"seeds"`
 }
 
 // WordPair links a real word with its "trap" version for Easy mode.
+// The ID is unique within its category and shared by the translations of the pair.
 type WordPair struct {
-	Real string `json:"real"`
-	Trap string `json:"trap"`
+	ID          string     `json:"id,omitempty" yaml:"id,omitempty"`
+	Real        string     `json:"real" yaml:"real"`
+	Trap        string     `json:"trap" yaml:"trap"`
+	Difficulty  Difficulty `json:"difficulty,omitempty" yaml:"difficulty,omitempty"`   // Empty means unrated (medium)
+	Hint        string     `json:"hint,omitempty" yaml:"hint,omitempty"`               // Vague clue given to the impostor in Easy hint mode
+	Description string     `json:"description,omitempty" yaml:"description,omitempty"` // Short definition of the real word, shown after the game
+	Tags        []string   `json:"tags,omitempty" yaml:"tags,omitempty"`
 }
 
+// Difficulty rates how hard it is to tell the real word from its trap.
+type Difficulty string
+
+const (
+	DifficultyEasy   Difficulty = "easy"   // "Sun" / "Moon"
+	DifficultyMedium Difficulty = "medium" // Also used for unrated pairs
+	DifficultyHard   Difficulty = "hard"   // "Frog" / "Toad"
+)
+
+// ContentFilter is what a lobby does with player names and chat messages containing blocked words.
+type ContentFilter string
+
+const (
+	FilterOff    ContentFilter = "off"
+	FilterMask   ContentFilter = "mask"   // Blocked words are replaced by asterisks
+	FilterReject ContentFilter = "reject" // The name or message is refused
+)
+
 // LobbyConfig defines the rules of the match.
 type LobbyConfig struct {
-	Mode           GameMode `json:"mode"`
-	Language       string   `json:"language"` // "en" or "es"
-	Categories     []string `json:"categories"`
-	Rounds         int      `json:"rounds"`
-	VotingTimeSecs int      `json:"voting_time_secs"`
+	Mode           GameMode      `json:"mode"`
+	Language       string        `json:"language"` // Language code, see game.LookupLanguage
+	Categories     []string      `json:"categories"`
+	Rounds         int           `json:"rounds"`
+	VotingTimeSecs int           `json:"voting_time_secs"`
+	MaxPlayers     int           `json:"max_players"`              // 0 means unlimited
+	Difficulty     Difficulty    `json:"difficulty,omitempty"`     // Only draw pairs of this difficulty; empty means any
+	Category       string        `json:"category,omitempty"`       // ID of the category last played
+	ContentFilter  ContentFilter `json:"content_filter,omitempty"` // Empty means FilterOff
+	Adaptive       bool          `json:"adaptive,omitempty"`       // Adjust Difficulty to the winners of the recent games
 }
 
 // LobbyState defines the current phase of the match.
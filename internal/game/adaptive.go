@@ -0,0 +1,41 @@
+package game
+
+import "impostor/internal/domain"
+
+// The adaptive difficulty looks at the winners of the last AdaptiveWindow games, once there are
+// at least adaptiveMinGames: when civilians win at least two games out of three the pairs get harder,
+// when they win at most one out of three they get easier.
+const (
+	AdaptiveWindow   = 8
+	adaptiveMinGames = 3
+)
+
+// recordWinner keeps the winner of a game for the adaptive difficulty. Caller must hold the lock.
+func (l *Lobby) recordWinner(civilians bool) {
+	l.recentWins = append(l.recentWins, civilians)
+	if len(l.recentWins) > AdaptiveWindow {
+		l.recentWins = l.recentWins[len(l.recentWins)-AdaptiveWindow:]
+	}
+}
+
+// drawDifficulty returns the difficulty of the next pair: Config.Difficulty, unless the lobby opted
+// into Config.Adaptive and one side keeps winning. Hard pairs are closer words, and synonyms for
+// ✨ Infinite. Caller must hold the lock.
+func (l *Lobby) drawDifficulty() domain.Difficulty {
+	if !l.Config.Adaptive || len(l.recentWins) < adaptiveMinGames {
+		return l.Config.Difficulty
+	}
+	civilians := 0
+	for _, won := range l.recentWins {
+		if won {
+			civilians++
+		}
+	}
+	switch games := len(l.recentWins); {
+	case civilians*3 >= games*2:
+		return domain.DifficultyHard
+	case civilians*3 <= games:
+		return domain.DifficultyEasy
+	}
+	return l.Config.Difficulty
+}
@@ -0,0 +1,107 @@
+package game
+
+import (
+	"context"
+	"impostor/internal/domain"
+	"testing"
+)
+
+// requestRecorder is an Infinite provider that remembers the difficulty it was asked for.
+type requestRecorder struct {
+	difficulty *domain.Difficulty
+}
+
+func (r requestRecorder) Draw(_ context.Context, req WordRequest) (domain.WordPair, error) {
+	*r.difficulty = req.Difficulty
+	return domain.WordPair{Real: "Comet", Trap: "Meteor"}, nil
+}
+
+// playGame plays a game of cat to the end, won by the civilians or the impostor.
+func playGame(t *testing.T, l *Lobby, cat domain.Category, civiliansWin bool) domain.WordPair {
+	t.Helper()
+	if err := l.StartGame(context.Background(), cat, domain.ModeHard); err != nil {
+		t.Fatal(err)
+	}
+	pair := l.CurrentPair
+
+	l.mu.Lock()
+	for id, p := range l.Players {
+		if (p.Role == domain.RoleImpostor) == civiliansWin {
+			l.finishGame(id)
+			break
+		}
+	}
+	l.mu.Unlock()
+	l.ResetGame()
+	return pair
+}
+
+func TestAdaptiveDifficulty(t *testing.T) {
+	var asked domain.Difficulty
+	h := NewHub()
+	h.SetInfiniteProvider(requestRecorder{&asked})
+	l, _ := h.CreateLobby("adaptive", nil)
+	l.Config.Language = "en"
+	for _, id := range []string{"p1", "p2", "p3"} {
+		l.AddPlayerSafe(&domain.Player{ID: id, Name: id})
+	}
+	cat := domain.Category{ID: "sky", Name: "Sky", Pairs: []domain.WordPair{
+		{ID: "sun-moon", Real: "Sun", Trap: "Moon", Difficulty: domain.DifficultyEasy},
+		{ID: "star-planet", Real: "Star", Trap: "Planet"},
+		{ID: "comet-meteor", Real: "Comet", Trap: "Meteor", Difficulty: domain.DifficultyHard},
+	}}
+
+	// Not opted in: civilians keep winning but any pair can be drawn
+	for range AdaptiveWindow {
+		playGame(t, l, cat, true)
+	}
+	l.Config.Adaptive = true
+	for range 5 {
+		if pair := playGame(t, l, cat, true); pair.ID != "comet-meteor" {
+			t.Fatalf("civilians won every game but drew %q, want the hard pair", pair.ID)
+		}
+	}
+	playGame(t, l, infiniteCategory(), true)
+	if asked != domain.DifficultyHard {
+		t.Errorf("Infinite asked for %q, want hard", asked)
+	}
+
+	// The impostor wins the last games: the pairs get easier again
+	for range AdaptiveWindow {
+		playGame(t, l, cat, false)
+	}
+	if pair := playGame(t, l, cat, false); pair.ID != "sun-moon" {
+		t.Errorf("the impostor won every game but drew %q, want the easy pair", pair.ID)
+	}
+
+	// Balanced games keep the chosen difficulty
+	l.Config.Difficulty = domain.DifficultyMedium
+	for i := range AdaptiveWindow {
+		playGame(t, l, cat, i%2 == 0)
+	}
+	if pair := playGame(t, l, cat, true); pair.ID != "star-planet" {
+		t.Errorf("balanced games drew %q, want the medium pair", pair.ID)
+	}
+}
+
+func TestDrawDifficultyNeedsGames(t *testing.T) {
+	l := NewLobby("new", nil)
+	l.Config.Adaptive = true
+	l.Config.Difficulty = domain.DifficultyMedium
+	for i := range adaptiveMinGames - 1 {
+		l.recordWinner(true)
+		if got := l.drawDifficulty(); got != domain.DifficultyMedium {
+			t.Errorf("after %d games: %q, want the chosen difficulty", i+1, got)
+		}
+	}
+	l.recordWinner(true)
+	if got := l.drawDifficulty(); got != domain.DifficultyHard {
+		t.Errorf("after %d civilian wins: %q, want hard", adaptiveMinGames, got)
+	}
+	for range 2 * AdaptiveWindow {
+		l.recordWinner(false)
+	}
+	if len(l.recentWins) != AdaptiveWindow {
+		t.Errorf("kept %d games, want %d", len(l.recentWins), AdaptiveWindow)
+	}
+}
@@ -0,0 +1,284 @@
+package game
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"impostor/internal/domain"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Relations of an association graph, named after the Datamuse parameters they stand in for.
+const (
+	RelationSynonym = "syn" // Means the same: pairs rated hard
+	RelationTrigger = "trg" // Is evoked by the word: pairs rated medium
+)
+
+// maxAssociations mirrors the max=20 of the Datamuse queries: traps are picked among the best related words.
+const maxAssociations = 20
+
+// Association is a word related to another, with a relatedness score (higher is closer).
+type Association struct {
+	Word  string
+	Score int
+}
+
+// AssociationGraph is a word-relatedness dataset for one language.
+type AssociationGraph struct {
+	related map[string]map[string][]Association // Relation -> lowercased word -> related words, best first
+	words   map[string][]string                 // Relation -> words with at least one related word
+}
+
+// ParseAssociations reads a graph in tab-separated format, one relation per line:
+//
+//	word	relation	related word	score
+//
+// relation is "syn" or "trg" and the score is an optional non-negative integer (1 by default).
+// Empty lines and lines starting with # are ignored.
+func ParseAssociations(r io.Reader) (*AssociationGraph, error) {
+	g := &AssociationGraph{
+		related: map[string]map[string][]Association{RelationSynonym: {}, RelationTrigger: {}},
+		words:   make(map[string][]string),
+	}
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 && len(fields) != 4 {
+			return nil, fmt.Errorf("line %d: expected 3 or 4 tab-separated fields, got %d", n, len(fields))
+		}
+		word, relation, other := strings.TrimSpace(fields[0]), fields[1], strings.TrimSpace(fields[2])
+		byWord, ok := g.related[relation]
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown relation %q (want %s or %s)", n, relation, RelationSynonym, RelationTrigger)
+		}
+		if word == "" || other == "" {
+			return nil, fmt.Errorf("line %d: empty word", n)
+		}
+		score := 1
+		if len(fields) == 4 {
+			var err error
+			if score, err = strconv.Atoi(strings.TrimSpace(fields[3])); err != nil || score < 0 {
+				return nil, fmt.Errorf("line %d: invalid score %q", n, fields[3])
+			}
+		}
+		if strings.EqualFold(word, other) {
+			continue
+		}
+
+		key := strings.ToLower(word)
+		if len(byWord[key]) == 0 {
+			g.words[relation] = append(g.words[relation], word)
+		}
+		byWord[key] = append(byWord[key], Association{Word: other, Score: score})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, byWord := range g.related {
+		for _, related := range byWord {
+			sort.SliceStable(related, func(i, j int) bool { return related[i].Score > related[j].Score })
+		}
+	}
+	return g, nil
+}
+
+// Len returns the number of words of the graph with at least one related word.
+func (g *AssociationGraph) Len() int {
+	seen := make(map[string]bool)
+	for _, words := range g.words {
+		for _, w := range words {
+			seen[strings.ToLower(w)] = true
+		}
+	}
+	return len(seen)
+}
+
+// Related returns the words linked to word by relation, best first, at most maxAssociations of them.
+func (g *AssociationGraph) Related(word, relation string) []Association {
+	related := g.related[relation][strings.ToLower(word)]
+	if len(related) > maxAssociations {
+		related = related[:maxAssociations]
+	}
+	return related
+}
+
+// RandomPair builds a pair with the same rules as the Datamuse client: a random seed word, and as trap
+// one of its triggers (medium) or synonyms (hard), picked at random weighted by score.
+// Trigger pairs get another trigger as hint. difficulty selects the relation; empty picks one at random.
+func (g *AssociationGraph) RandomPair(difficulty domain.Difficulty) (domain.WordPair, bool) {
+	return g.randomPair(difficulty, nil)
+}
+
+// RandomPairFrom is RandomPair with the seed drawn among seeds, such as those of an Infinite theme.
+// Seeds missing from the graph are ignored.
+func (g *AssociationGraph) RandomPairFrom(seeds []string, difficulty domain.Difficulty) (domain.WordPair, bool) {
+	return g.randomPair(difficulty, seeds)
+}
+
+func (g *AssociationGraph) randomPair(difficulty domain.Difficulty, only []string) (domain.WordPair, bool) {
+	relations := []string{RelationTrigger, RelationSynonym}
+	switch {
+	case difficulty == domain.DifficultyHard:
+		relations = []string{RelationSynonym, RelationTrigger}
+	case difficulty == "" && rand.Float32() > 0.5:
+		relations = []string{RelationSynonym, RelationTrigger}
+	}
+
+	for _, relation := range relations {
+		seeds := g.words[relation]
+		if only != nil {
+			seeds = nil
+			for _, seed := range only {
+				if len(g.related[relation][strings.ToLower(seed)]) > 0 {
+					seeds = append(seeds, seed)
+				}
+			}
+		}
+		if len(seeds) == 0 {
+			continue
+		}
+		seed := seeds[rand.Intn(len(seeds))]
+		related := g.Related(seed, relation)
+		trap := pickByScore(related)
+
+		pair := domain.WordPair{
+			Real:       strings.Title(seed),
+			Trap:       strings.Title(trap.Word),
+			Difficulty: domain.DifficultyMedium,
+		}
+		if relation == RelationSynonym {
+			pair.Difficulty = domain.DifficultyHard
+		} else {
+			// Another association makes a vague hint, as with Datamuse
+			for _, i := range rand.Perm(len(related)) {
+				if w := related[i].Word; !strings.EqualFold(w, trap.Word) {
+					pair.Hint = strings.Title(w)
+					break
+				}
+			}
+		}
+		return pair, true
+	}
+	return domain.WordPair{}, false
+}
+
+// pickByScore picks a random association, with odds proportional to the score (at least 1).
+func pickByScore(related []Association) Association {
+	total := 0
+	for _, a := range related {
+		total += max(a.Score, 1)
+	}
+	n := rand.Intn(total)
+	for _, a := range related {
+		n -= max(a.Score, 1)
+		if n < 0 {
+			return a
+		}
+	}
+	return related[len(related)-1]
+}
+
+// AssociationProvider is an offline WordProvider for the ✨ Infinite category,
+// drawing pairs from local association graphs instead of Datamuse.
+type AssociationProvider struct {
+	graphs map[string]*AssociationGraph // Language code -> graph
+}
+
+// NewAssociationProvider creates a provider from graphs keyed by language code.
+func NewAssociationProvider(graphs map[string]*AssociationGraph) *AssociationProvider {
+	return &AssociationProvider{graphs: graphs}
+}
+
+// LoadAssociationDir reads one graph per language from the .tsv files of dir (en.tsv, es.tsv...).
+func LoadAssociationDir(dir string) (*AssociationProvider, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.tsv"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no .tsv association files in %s", dir)
+	}
+
+	graphs := make(map[string]*AssociationGraph, len(files))
+	for _, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		g, err := ParseAssociations(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(name), err)
+		}
+		graphs[strings.TrimSuffix(filepath.Base(name), ".tsv")] = g
+	}
+	return NewAssociationProvider(graphs), nil
+}
+
+// Languages returns the codes of the loaded graphs, sorted.
+func (p *AssociationProvider) Languages() []string {
+	codes := make([]string, 0, len(p.graphs))
+	for code := range p.graphs {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// Serves reports whether a graph is used for the language, see Draw.
+func (p *AssociationProvider) Serves(language string) bool {
+	_, ok := p.graph(language)
+	return ok
+}
+
+// Draw builds a pair from the graph of the language, with the seed drawn among the words of the requested
+// theme if any. The graph is looked up along the fallback chain of the language, like its words: "pt-BR"
+// uses the "pt" graph and an empty or unknown language the English one. The lookup stops at the first
+// language with words of its own, so French players get ErrNoWords rather than English words.
+func (p *AssociationProvider) Draw(ctx context.Context, req WordRequest) (domain.WordPair, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.WordPair{}, err
+	}
+	g, ok := p.graph(req.Language)
+	if !ok {
+		return domain.WordPair{}, ErrNoWords
+	}
+	var seeds []string
+	if req.Theme != nil {
+		seeds = req.Theme.Seeds
+	}
+	if pair, ok := g.randomPair(req.Difficulty, seeds); ok {
+		return pair, nil
+	}
+	return domain.WordPair{}, ErrNoWords
+}
+
+// graph returns the graph used for a language, see Draw.
+func (p *AssociationProvider) graph(language string) (*AssociationGraph, bool) {
+	d := CurrentDictionary()
+	for _, code := range LanguageChain(language) {
+		if g, ok := p.graphs[code]; ok {
+			return g, true
+		}
+		if _, ok := d.Lookup(code); ok {
+			return nil, false
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,132 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"impostor/internal/domain"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testAssociations = `# word	relation	related	score
+ocean	trg	wave	900
+ocean	trg	salt	100
+ocean	syn	sea	1000
+ocean	syn	ocean
+`
+
+func TestParseAssociations(t *testing.T) {
+	g, err := ParseAssociations(strings.NewReader(testAssociations))
+	if err != nil {
+		t.Fatalf("ParseAssociations: %v", err)
+	}
+	if g.Len() != 1 {
+		t.Errorf("Len = %d, want 1", g.Len())
+	}
+	related := g.Related("Ocean", RelationTrigger)
+	if len(related) != 2 || related[0].Word != "wave" || related[1].Score != 100 {
+		t.Errorf("triggers = %+v, want wave then salt", related)
+	}
+	if related := g.Related("ocean", RelationSynonym); len(related) != 1 {
+		t.Errorf("synonyms = %+v, want only sea (self-relations are skipped)", related)
+	}
+
+	for name, input := range map[string]string{
+		"fields":   "ocean\ttrg",
+		"relation": "ocean\tant\tland",
+		"empty":    "ocean\ttrg\t ",
+		"score":    "ocean\ttrg\twave\thigh",
+		"negative": "ocean\ttrg\twave\t-1",
+	} {
+		_, err := ParseAssociations(strings.NewReader("# header\n" + input))
+		if err == nil || !strings.Contains(err.Error(), "line 2") {
+			t.Errorf("%s: error = %v, want one on line 2", name, err)
+		}
+	}
+}
+
+func TestAssociationGraphRandomPair(t *testing.T) {
+	g, _ := ParseAssociations(strings.NewReader(testAssociations))
+
+	traps := make(map[string]int)
+	for range 500 {
+		pair, ok := g.RandomPair(domain.DifficultyMedium)
+		if !ok || pair.Real != "Ocean" || pair.Difficulty != domain.DifficultyMedium {
+			t.Fatalf("medium pair = %+v, %v", pair, ok)
+		}
+		if pair.Hint == "" || pair.Hint == pair.Trap {
+			t.Errorf("pair %+v should have another trigger as hint", pair)
+		}
+		traps[pair.Trap]++
+	}
+	// Wave scores 9 times more than salt
+	if traps["Wave"] < 3*traps["Salt"] {
+		t.Errorf("traps = %v, want mostly Wave", traps)
+	}
+
+	pair, ok := g.RandomPair(domain.DifficultyHard)
+	if !ok || pair.Trap != "Sea" || pair.Difficulty != domain.DifficultyHard || pair.Hint != "" {
+		t.Errorf("hard pair = %+v, %v, want the synonym without hint", pair, ok)
+	}
+
+	empty, _ := ParseAssociations(strings.NewReader(""))
+	if _, ok := empty.RandomPair(""); ok {
+		t.Error("an empty graph should not build pairs")
+	}
+}
+
+func TestAssociationProvider(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "es.tsv", "perro\tsyn\tcan\t10\n")
+	writeFile(t, dir, "notes.txt", "ignored")
+
+	p, err := LoadAssociationDir(dir)
+	if err != nil {
+		t.Fatalf("LoadAssociationDir: %v", err)
+	}
+	if got := p.Languages(); len(got) != 1 || got[0] != "es" {
+		t.Errorf("Languages = %v, want [es]", got)
+	}
+
+	for _, language := range []string{"es", "es-MX"} {
+		if !p.Serves(language) {
+			t.Errorf("Serves(%s) = false", language)
+		}
+		pair, err := p.Draw(context.Background(), WordRequest{Language: language})
+		if err != nil || pair.Real != "Perro" || pair.Trap != "Can" {
+			t.Errorf("Draw(%s) = %+v, %v", language, pair, err)
+		}
+	}
+	// No fallback to other languages: the game falls back to local words instead
+	if _, err := p.Draw(context.Background(), WordRequest{Language: "en"}); !errors.Is(err, ErrNoWords) {
+		t.Errorf("Draw(en) error = %v, want ErrNoWords", err)
+	}
+
+	// Languages are looked up like their words: lobbies without a language play in English
+	writeFile(t, dir, "en.tsv", "ocean\tsyn\tsea\n")
+	if p, err = LoadAssociationDir(dir); err != nil {
+		t.Fatalf("LoadAssociationDir: %v", err)
+	}
+	for _, language := range []string{"", "en-GB", "xx"} {
+		if pair, err := p.Draw(context.Background(), WordRequest{Language: language}); err != nil || pair.Real != "Ocean" {
+			t.Errorf("Draw(%q) = %+v, %v, want the English graph", language, pair, err)
+		}
+	}
+	if p.Serves("fr") {
+		t.Error("French has words of its own, it should not use the English graph")
+	}
+
+	if _, err := LoadAssociationDir(filepath.Join(dir, "missing")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("LoadAssociationDir(missing) error = %v, want fs.ErrNotExist", err)
+	}
+
+	writeFile(t, dir, "en.tsv", "ocean\tfoo\tsea\n")
+	if _, err := LoadAssociationDir(dir); err == nil || !strings.Contains(err.Error(), "en.tsv") {
+		t.Errorf("invalid file error = %v, want one naming en.tsv", err)
+	}
+	if _, err := LoadAssociationDir(t.TempDir()); err == nil {
+		t.Error("a directory without .tsv files should be an error")
+	}
+}
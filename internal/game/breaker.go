@@ -0,0 +1,132 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned instead of calling a service that keeps failing.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// Defaults of the Datamuse circuit breaker.
+const (
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCooldown  = time.Minute
+)
+
+// Circuit breaker states.
+const (
+	BreakerClosed   = "closed"    // Calls go through
+	BreakerOpen     = "open"      // Calls are skipped until the cooldown ends
+	BreakerHalfOpen = "half-open" // One trial call decides whether to close or reopen
+)
+
+// CircuitBreaker skips calls to a service for a cooldown after threshold failures in a row.
+// Once the cooldown is over a single trial call goes through: success closes the breaker,
+// failure opens it for another cooldown.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time // Replaced in tests
+
+	mu        sync.Mutex
+	failures  int // Consecutive failures
+	openUntil time.Time
+	trial     bool // A half-open trial call is in flight
+	lastErr   error
+	trips     uint64
+}
+
+// BreakerStats is a snapshot of a circuit breaker.
+type BreakerStats struct {
+	State     string     `json:"state"`
+	Failures  int        `json:"consecutive_failures"`
+	Trips     uint64     `json:"trips"` // Times the breaker opened
+	OpenUntil *time.Time `json:"open_until,omitempty"`
+	LastError string     `json:"last_error,omitempty"`
+}
+
+// NewCircuitBreaker creates a closed breaker. Non-positive values use the defaults.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold <= 0 {
+		threshold = DefaultBreakerThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = DefaultBreakerCooldown
+	}
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// Allow reports whether a call may go through. Every allowed call must be followed by Record.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state() {
+	case BreakerOpen:
+		return false
+	case BreakerHalfOpen:
+		if b.trial {
+			return false
+		}
+		b.trial = true
+	}
+	return true
+}
+
+// Record reports the outcome of an allowed call. Success closes the breaker; a failure opens it
+// after threshold of them in a row or a failed trial. Calls the caller gave up on, canceled or past
+// the deadline of its context, don't count.
+func (b *CircuitBreaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		b.trial = false
+		return
+	}
+	if err == nil {
+		b.failures = 0
+		b.trial = false
+		b.openUntil = time.Time{}
+		return
+	}
+
+	b.failures++
+	b.lastErr = err
+	if b.trial || b.failures >= b.threshold {
+		b.trial = false
+		b.openUntil = b.now().Add(b.cooldown)
+		b.trips++
+	}
+}
+
+// Stats returns the current state of the breaker.
+func (b *CircuitBreaker) Stats() BreakerStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := BreakerStats{State: b.state(), Failures: b.failures, Trips: b.trips}
+	if stats.State == BreakerOpen {
+		until := b.openUntil
+		stats.OpenUntil = &until
+	}
+	if b.lastErr != nil {
+		stats.LastError = b.lastErr.Error()
+	}
+	return stats
+}
+
+// state must be called with the lock held.
+func (b *CircuitBreaker) state() string {
+	switch {
+	case b.openUntil.IsZero():
+		return BreakerClosed
+	case b.now().Before(b.openUntil):
+		return BreakerOpen
+	default:
+		return BreakerHalfOpen
+	}
+}
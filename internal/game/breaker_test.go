@@ -0,0 +1,55 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	b := NewCircuitBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+	down := errors.New("down")
+
+	b.Allow()
+	b.Record(down)
+	if !b.Allow() {
+		t.Fatal("breaker opened before the threshold")
+	}
+	b.Record(down)
+	if b.Allow() {
+		t.Fatal("breaker still closed after 2 failures")
+	}
+	stats := b.Stats()
+	if stats.State != BreakerOpen || stats.Trips != 1 || stats.LastError != "down" || stats.OpenUntil == nil {
+		t.Errorf("Stats() = %+v", stats)
+	}
+
+	// After the cooldown, a single trial call goes through; failing it reopens the breaker
+	now = now.Add(time.Minute)
+	if !b.Allow() || b.Allow() {
+		t.Fatal("half-open breaker should allow exactly one trial")
+	}
+	b.Record(down)
+	if b.Allow() || b.Stats().Trips != 2 {
+		t.Fatalf("failed trial should reopen the breaker: %+v", b.Stats())
+	}
+
+	// A canceled trial doesn't count, a successful one closes the breaker
+	now = now.Add(time.Minute)
+	b.Allow()
+	b.Record(context.Canceled)
+	if !b.Allow() {
+		t.Fatal("canceled trial should let another trial through")
+	}
+	b.Record(context.DeadlineExceeded)
+	if !b.Allow() {
+		t.Fatal("trial past the caller's deadline should let another trial through")
+	}
+	b.Record(nil)
+	if stats := b.Stats(); stats.State != BreakerClosed || stats.Failures != 0 {
+		t.Errorf("Stats() after a successful trial = %+v", stats)
+	}
+}
@@ -0,0 +1,80 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MinPlayers is the smallest lobby that makes sense to play (one impostor, two civilians).
+const MinPlayers = 3
+
+var (
+	ErrTooManyLobbies = errors.New("server lobby limit reached")
+	ErrLobbyFull      = errors.New("lobby is full")
+	ErrNotLeader      = errors.New("only the leader can do this")
+)
+
+// Limits bounds the resources a Hub hands out. A zero value means unlimited.
+type Limits struct {
+	MaxLobbies        int // Concurrent lobbies in the Hub
+	DefaultMaxPlayers int // Capacity of a new lobby
+	MaxPlayersCeiling int // Highest capacity a leader can set
+}
+
+// DefaultLimits returns the limits used when nothing is configured.
+func DefaultLimits() Limits {
+	return Limits{
+		MaxLobbies:        1000,
+		DefaultMaxPlayers: 12,
+		MaxPlayersCeiling: 20,
+	}
+}
+
+// Validate checks that the capacity of new lobbies is one a leader could set.
+func (l Limits) Validate() error {
+	if l.MaxPlayersCeiling != 0 && l.MaxPlayersCeiling < MinPlayers {
+		return fmt.Errorf("max players ceiling %d is below %d", l.MaxPlayersCeiling, MinPlayers)
+	}
+	if l.DefaultMaxPlayers != 0 && l.DefaultMaxPlayers < MinPlayers {
+		return fmt.Errorf("default max players %d is below %d", l.DefaultMaxPlayers, MinPlayers)
+	}
+	if l.MaxPlayersCeiling != 0 && (l.DefaultMaxPlayers == 0 || l.DefaultMaxPlayers > l.MaxPlayersCeiling) {
+		return fmt.Errorf("default max players must not exceed the ceiling of %d", l.MaxPlayersCeiling)
+	}
+	return nil
+}
+
+// SetLimits configures the Hub limits. Must be called before the Hub is used.
+func (h *Hub) SetLimits(limits Limits) {
+	h.limits = limits
+}
+
+// SetMaxPlayers changes the lobby capacity on behalf of the leader.
+// The value must be within [MinPlayers, ceiling] and can't drop below the players already in.
+func (l *Lobby) SetMaxPlayers(requesterID string, max int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if p, ok := l.Players[requesterID]; !ok || !p.IsLeader {
+		return ErrNotLeader
+	}
+	if l.maxPlayersCeiling == 0 && max < MinPlayers {
+		return fmt.Errorf("max players must be at least %d", MinPlayers)
+	}
+	if l.maxPlayersCeiling > 0 && (max < MinPlayers || max > l.maxPlayersCeiling) {
+		return fmt.Errorf("max players must be between %d and %d", MinPlayers, l.maxPlayersCeiling)
+	}
+	if max < len(l.Players) {
+		return fmt.Errorf("there are already %d players in the lobby", len(l.Players))
+	}
+
+	l.Config.MaxPlayers = max
+	l.touch()
+
+	msg := map[string]interface{}{
+		"type":        "LOBBY_CONFIG",
+		"max_players": max,
+	}
+	l.broadcastInternal(msg)
+	return nil
+}
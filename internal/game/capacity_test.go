@@ -0,0 +1,113 @@
+package game
+
+import (
+	"errors"
+	"impostor/internal/domain"
+	"testing"
+)
+
+func TestHubLobbyCap(t *testing.T) {
+	h := NewHub()
+	h.SetLimits(Limits{MaxLobbies: 1})
+
+	if _, err := h.CreateLobby("a", nil); err != nil {
+		t.Fatalf("CreateLobby() error = %v", err)
+	}
+	if _, err := h.CreateLobby("b", nil); !errors.Is(err, ErrTooManyLobbies) {
+		t.Errorf("CreateLobby() error = %v, want ErrTooManyLobbies", err)
+	}
+
+	h.DeleteLobby("a")
+	if _, err := h.CreateLobby("b", nil); err != nil {
+		t.Errorf("CreateLobby() after delete error = %v", err)
+	}
+}
+
+func TestLobbyCapacity(t *testing.T) {
+	h := NewHub()
+	h.SetLimits(Limits{DefaultMaxPlayers: 3, MaxPlayersCeiling: 5})
+	l, _ := h.CreateLobby("lobby", nil)
+
+	for _, id := range []string{"p1", "p2", "p3"} {
+		if _, err := l.AddPlayerSafe(&domain.Player{ID: id, Name: id}); err != nil {
+			t.Fatalf("AddPlayerSafe(%s) error = %v", id, err)
+		}
+	}
+	if _, err := l.AddPlayerSafe(&domain.Player{ID: "p4", Name: "p4"}); !errors.Is(err, ErrLobbyFull) {
+		t.Errorf("AddPlayerSafe() error = %v, want ErrLobbyFull", err)
+	}
+	// Reconnecting players are not blocked by the cap.
+	if _, err := l.AddPlayerSafe(&domain.Player{ID: "p2", Name: "p2"}); err != nil {
+		t.Errorf("AddPlayerSafe() rejoin error = %v", err)
+	}
+
+	if err := l.SetMaxPlayers("p2", 4); !errors.Is(err, ErrNotLeader) {
+		t.Errorf("SetMaxPlayers() by non-leader error = %v, want ErrNotLeader", err)
+	}
+	if err := l.SetMaxPlayers("p1", 6); err == nil {
+		t.Error("SetMaxPlayers() above the ceiling should fail")
+	}
+	if err := l.SetMaxPlayers("p1", 4); err != nil {
+		t.Fatalf("SetMaxPlayers() error = %v", err)
+	}
+	if _, err := l.AddPlayerSafe(&domain.Player{ID: "p4", Name: "p4"}); err != nil {
+		t.Errorf("AddPlayerSafe() after raising the cap error = %v", err)
+	}
+}
+
+func TestSetMaxPlayersWithoutCeiling(t *testing.T) {
+	h := NewHub()
+	h.SetLimits(Limits{})
+	l, _ := h.CreateLobby("lobby", nil)
+	l.AddPlayerSafe(&domain.Player{ID: "p1", Name: "p1"})
+
+	if err := l.SetMaxPlayers("p1", 2); err == nil || err.Error() != "max players must be at least 3" {
+		t.Errorf("SetMaxPlayers(2) error = %v", err)
+	}
+	if err := l.SetMaxPlayers("p1", 50); err != nil {
+		t.Errorf("SetMaxPlayers(50) without a ceiling error = %v", err)
+	}
+}
+
+func TestLimitsValidate(t *testing.T) {
+	for _, tt := range []struct {
+		limits Limits
+		valid  bool
+	}{
+		{DefaultLimits(), true},
+		{Limits{}, true},
+		{Limits{DefaultMaxPlayers: 30}, true},
+		{Limits{DefaultMaxPlayers: 30, MaxPlayersCeiling: 20}, false},
+		{Limits{MaxPlayersCeiling: 20}, false},
+		{Limits{DefaultMaxPlayers: 2}, false},
+		{Limits{DefaultMaxPlayers: 2, MaxPlayersCeiling: 2}, false},
+	} {
+		if err := tt.limits.Validate(); (err == nil) != tt.valid {
+			t.Errorf("%+v.Validate() = %v, want valid = %v", tt.limits, err, tt.valid)
+		}
+	}
+}
+
+func TestLeaderKeepsLeadOnRejoin(t *testing.T) {
+	l := NewLobby("lobby", nil)
+	l.AddPlayerSafe(&domain.Player{ID: "p1", Name: "Ana"})
+	l.AddPlayerSafe(&domain.Player{ID: "p2", Name: "Bob"})
+	old, reconnected := &fakeClient{}, &fakeClient{}
+	l.RegisterClient("p1", old)
+
+	// The leader reconnects before their old connection is noticed as closed
+	if isFirst, err := l.AddPlayerSafe(&domain.Player{ID: "p1", Name: "Ana"}); err != nil || isFirst {
+		t.Fatalf("AddPlayerSafe() rejoin = %v, %v", isFirst, err)
+	}
+	l.RegisterClient("p1", reconnected)
+	if left, _ := l.Disconnect("p1", old); left {
+		t.Error("the old connection closing removed the reconnected player")
+	}
+	if err := l.SetMaxPlayers("p1", 5); err != nil {
+		t.Errorf("SetMaxPlayers() by the reconnected leader error = %v", err)
+	}
+
+	if left, _ := l.Disconnect("p1", reconnected); !left || l.HasPlayer("p1") {
+		t.Error("the player should leave when their current connection closes")
+	}
+}
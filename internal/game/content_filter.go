@@ -0,0 +1,306 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"impostor/internal/domain"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrContentRejected is returned for a player name or chat message with a blocked word,
+	// when the lobby's content filter is in reject mode.
+	ErrContentRejected  = errors.New("message contains a blocked word")
+	ErrPlayerNotInLobby = errors.New("player is not in the lobby")
+)
+
+// ParseContentFilter validates a content filter mode. Empty means FilterOff.
+func ParseContentFilter(s string) (domain.ContentFilter, error) {
+	switch mode := domain.ContentFilter(strings.ToLower(strings.TrimSpace(s))); mode {
+	case "":
+		return domain.FilterOff, nil
+	case domain.FilterOff, domain.FilterMask, domain.FilterReject:
+		return mode, nil
+	}
+	return "", fmt.Errorf("content filter must be %s, %s or %s", domain.FilterOff, domain.FilterMask, domain.FilterReject)
+}
+
+// ContentFilter finds the words of per-language blocklists in player names and chat messages.
+// Words are compared after lowercasing, removing accents and undoing leetspeak ("Ch3@t" matches "cheat"),
+// and only as whole words, so "class" doesn't match "ass". Words are not stemmed either: "cheat" doesn't
+// catch "cheats" or "cheating", so blocklists must list the inflected forms to block.
+// Blocklist entries may have several words.
+// A nil *ContentFilter blocks nothing.
+type ContentFilter struct {
+	lists map[string][][]string // Language -> normalized entries, split into words
+}
+
+// NewContentFilter builds a filter from the blocklist of each language.
+func NewContentFilter(lists map[string][]string) *ContentFilter {
+	f := &ContentFilter{lists: make(map[string][][]string, len(lists))}
+	for lang, words := range lists {
+		for _, entry := range words {
+			var normalized []string
+			for _, t := range tokenize(entry) {
+				normalized = append(normalized, t.word)
+			}
+			if len(normalized) > 0 {
+				f.lists[lang] = append(f.lists[lang], normalized)
+			}
+		}
+	}
+	return f
+}
+
+// LoadContentFilterDir reads one blocklist per language from dir, named after the language
+// (en.txt, es.txt...), one word or phrase per line with # comments.
+func LoadContentFilterDir(dir string) (*ContentFilter, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no .txt blocklist files in %s", dir)
+	}
+
+	lists := make(map[string][]string, len(files))
+	for _, name := range files {
+		words, err := LoadBlocklist(name)
+		if err != nil {
+			return nil, err
+		}
+		lists[strings.TrimSuffix(filepath.Base(name), ".txt")] = words
+	}
+	return NewContentFilter(lists), nil
+}
+
+// Languages returns the languages with a blocklist, sorted.
+func (f *ContentFilter) Languages() []string {
+	if f == nil {
+		return []string{}
+	}
+	languages := make([]string, 0, len(f.lists))
+	for lang := range f.lists {
+		languages = append(languages, lang)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Mask returns text with the blocked words of the given languages replaced by asterisks,
+// and whether any was found. The blocklists of each language's fallback chain apply too,
+// which always ends with English.
+func (f *ContentFilter) Mask(text string, languages ...string) (string, bool) {
+	if f == nil || len(f.lists) == 0 {
+		return text, false
+	}
+	tokens := tokenize(text)
+	runes := []rune(text)
+	found := false
+	for _, entry := range f.entries(languages) {
+		for i := 0; i+len(entry) <= len(tokens); i++ {
+			if !matches(tokens[i:i+len(entry)], entry) {
+				continue
+			}
+			found = true
+			for _, t := range tokens[i : i+len(entry)] {
+				for j := t.start; j < t.end; j++ {
+					runes[j] = '*'
+				}
+			}
+		}
+	}
+	if !found {
+		return text, false
+	}
+	return string(runes), true
+}
+
+// CleanPairs returns the pairs without a blocked word of the language in their words or hint.
+func (f *ContentFilter) CleanPairs(pairs []domain.WordPair, language string) []domain.WordPair {
+	if f == nil || len(f.lists) == 0 {
+		return pairs
+	}
+	clean := make([]domain.WordPair, 0, len(pairs))
+	for _, p := range pairs {
+		if !f.blocks(language, p.Real, p.Trap, p.Hint) {
+			clean = append(clean, p)
+		}
+	}
+	return clean
+}
+
+// blocks reports whether any of the texts has a blocked word of the language.
+func (f *ContentFilter) blocks(language string, texts ...string) bool {
+	for _, text := range texts {
+		if _, found := f.Mask(text, language); found {
+			return true
+		}
+	}
+	return false
+}
+
+// entries returns the blocklists of the languages and their fallbacks, without repeating any.
+func (f *ContentFilter) entries(languages []string) [][]string {
+	var entries [][]string
+	seen := make(map[string]bool)
+	for _, language := range languages {
+		if language == "" {
+			language = DefaultLanguage
+		}
+		for _, code := range LanguageChain(language) {
+			if !seen[code] {
+				seen[code] = true
+				entries = append(entries, f.lists[code]...)
+			}
+		}
+	}
+	return entries
+}
+
+func matches(tokens []token, entry []string) bool {
+	for i, word := range entry {
+		if tokens[i].word != word {
+			return false
+		}
+	}
+	return true
+}
+
+// token is a word of a text, normalized, with its position in runes.
+type token struct {
+	word       string
+	start, end int
+}
+
+// tokenize splits text into normalized words. Leetspeak symbols count as letters inside words
+// ("sh!t", "@ss"), so they are part of the word they replace a letter of.
+func tokenize(text string) []token {
+	runes := []rune(text)
+	var tokens []token
+	start := -1
+	for i := 0; i <= len(runes); i++ {
+		if i < len(runes) && isWordRune(runes, i) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			tokens = append(tokens, token{word: normalizeWord(runes[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	return tokens
+}
+
+func isWordRune(runes []rune, i int) bool {
+	r := runes[i]
+	if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '@' || r == '$' {
+		return true
+	}
+	// "!" and "|" only stand for an i or l in the middle of a word, not as punctuation
+	if (r == '!' || r == '|') && i > 0 && i+1 < len(runes) {
+		return unicode.IsLetter(runes[i-1]) && unicode.IsLetter(runes[i+1])
+	}
+	return false
+}
+
+func normalizeWord(runes []rune) string {
+	var b strings.Builder
+	for _, r := range foldWord(string(runes)) {
+		if plain, ok := leetspeak[r]; ok {
+			r = plain
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+var leetspeak = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b',
+	'@': 'a', '$': 's', '!': 'i', '|': 'l',
+}
+
+// screen applies the lobby's content filter to a text written by a player in the given language:
+// the text unchanged when off or clean, masked in mask mode, ErrContentRejected in reject mode.
+// The blocklists of the lobby language and of the player's one are both checked. Caller must hold the lock.
+func (l *Lobby) screen(text, language string) (string, error) {
+	if l.Config.ContentFilter == domain.FilterOff || l.Config.ContentFilter == "" {
+		return text, nil
+	}
+	masked, found := l.contentFilter.Mask(text, l.Config.Language, language)
+	if !found {
+		return text, nil
+	}
+	if l.Config.ContentFilter == domain.FilterReject {
+		return "", ErrContentRejected
+	}
+	return masked, nil
+}
+
+// ContentFilterMode returns the content filter mode of the lobby, FilterOff when unset.
+func (l *Lobby) ContentFilterMode() domain.ContentFilter {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.Config.ContentFilter == "" {
+		return domain.FilterOff
+	}
+	return l.Config.ContentFilter
+}
+
+// SetContentFilter changes the content filter mode of the lobby on behalf of the leader.
+// Names of the players already in are not screened again.
+func (l *Lobby) SetContentFilter(requesterID string, mode domain.ContentFilter) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if p, ok := l.Players[requesterID]; !ok || !p.IsLeader {
+		return ErrNotLeader
+	}
+
+	l.Config.ContentFilter = mode
+	l.touch()
+
+	msg := map[string]interface{}{
+		"type":           "LOBBY_CONFIG",
+		"content_filter": mode,
+	}
+	l.broadcastInternal(msg)
+	return nil
+}
+
+// Chat broadcasts a chat message from a player, once screened by the content filter.
+// Returns ErrContentRejected when the lobby rejects it, in which case nothing is sent.
+func (l *Lobby) Chat(playerID, text string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	p, ok := l.Players[playerID]
+	if !ok {
+		return ErrPlayerNotInLobby
+	}
+	text, err := l.screen(text, p.Language)
+	if err != nil {
+		return err
+	}
+	l.touch()
+
+	msg := map[string]interface{}{
+		"type": "CHAT_MESSAGE",
+		"from": p.Name,
+		"text": text,
+	}
+	l.broadcastInternal(msg)
+	return nil
+}
+
+// SetContentFilter sets the blocklists and the content filter mode of the lobbies created afterwards.
+// Leaders can change the mode of their lobby; with a nil filter, no mode blocks anything.
+func (h *Hub) SetContentFilter(f *ContentFilter, mode domain.ContentFilter) {
+	h.contentFilter = f
+	h.contentFilterMode = mode
+}
@@ -0,0 +1,123 @@
+package game
+
+import (
+	"errors"
+	"impostor/internal/domain"
+	"testing"
+)
+
+func TestContentFilterMask(t *testing.T) {
+	f := NewContentFilter(map[string][]string{
+		"en": {"heck", "dang it"},
+		"es": {"caramba"},
+	})
+
+	tests := []struct {
+		text, language, want string
+	}{
+		{"oh heck!", "en", "oh ****!"},
+		{"OH HÉCK", "en", "OH ****"},
+		{"h3ck, h€ck and h@ck", "en", "****, h€ck and h@ck"},
+		{"4ll h3ck$", "en", "4ll h3ck$"}, // Not the same word
+		{"the heckler", "en", "the heckler"},
+		{"dang it all", "en", "**** ** all"},
+		{"dang", "en", "dang"},
+		{"¡Cár4mba!", "es", "¡*******!"},
+		{"caramba", "en", "caramba"},
+		{"heck", "es-MX", "****"}, // English always applies
+		{"caramba", "es-MX", "*******"},
+	}
+	for _, tt := range tests {
+		got, found := f.Mask(tt.text, tt.language)
+		if got != tt.want || found != (got != tt.text) {
+			t.Errorf("Mask(%q, %s) = %q, %v, want %q", tt.text, tt.language, got, found, tt.want)
+		}
+	}
+
+	if got, found := (*ContentFilter)(nil).Mask("heck", "en"); got != "heck" || found {
+		t.Errorf("nil filter masked %q", got)
+	}
+}
+
+func TestLoadContentFilterDir(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "en.txt", "# Kids table\nheck\n")
+	writeFile(t, dir, "es.txt", "caramba\n")
+	f, err := LoadContentFilterDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.Languages(); len(got) != 2 || got[0] != "en" || got[1] != "es" {
+		t.Errorf("Languages() = %v", got)
+	}
+	if _, found := f.Mask("caramba", "es"); !found {
+		t.Error("es.txt not loaded")
+	}
+
+	if _, err := LoadContentFilterDir(t.TempDir()); err == nil {
+		t.Error("an empty directory should fail")
+	}
+}
+
+func TestLobbyContentFilter(t *testing.T) {
+	h := NewHub()
+	h.SetContentFilter(NewContentFilter(map[string][]string{"en": {"heck"}, "es": {"caramba"}}), domain.FilterMask)
+	l, _ := h.CreateLobby("family", nil)
+	leader := &domain.Player{ID: "p1", Name: "Heck Yeah"}
+	l.AddPlayerSafe(leader)
+	client := &fakeClient{}
+	l.RegisterClient("p1", client)
+	l.AddPlayerSafe(&domain.Player{ID: "p2", Name: "Ana", Language: "es"})
+
+	if leader.Name != "**** Yeah" {
+		t.Errorf("name = %q, want it masked", leader.Name)
+	}
+
+	// The lobby language and the player's one both apply
+	if err := l.Chat("p2", "caramba, heck"); err != nil {
+		t.Fatal(err)
+	}
+	chat, _ := client.sent[len(client.sent)-1].(map[string]interface{})
+	if chat["type"] != "CHAT_MESSAGE" || chat["from"] != "Ana" || chat["text"] != "*******, ****" {
+		t.Errorf("chat = %v, want the masked text", chat)
+	}
+
+	if err := l.SetContentFilter("p2", domain.FilterReject); !errors.Is(err, ErrNotLeader) {
+		t.Errorf("SetContentFilter() by a player: err = %v", err)
+	}
+	if err := l.SetContentFilter("p1", domain.FilterReject); err != nil {
+		t.Fatal(err)
+	}
+	sent := len(client.sent)
+	if err := l.Chat("p2", "HECK"); !errors.Is(err, ErrContentRejected) {
+		t.Errorf("Chat() in reject mode: err = %v", err)
+	}
+	if len(client.sent) != sent {
+		t.Error("a rejected message was broadcast")
+	}
+	if _, err := l.AddPlayerSafe(&domain.Player{ID: "p3", Name: "h3ck"}); !errors.Is(err, ErrContentRejected) {
+		t.Errorf("AddPlayerSafe() in reject mode: err = %v", err)
+	}
+	if l.HasPlayer("p3") {
+		t.Error("rejected player joined")
+	}
+	if err := l.Chat("stranger", "hi"); !errors.Is(err, ErrPlayerNotInLobby) {
+		t.Errorf("Chat() by a stranger: err = %v", err)
+	}
+
+	l.SetContentFilter("p1", domain.FilterOff)
+	if err := l.Chat("p2", "heck"); err != nil {
+		t.Errorf("Chat() with the filter off: err = %v", err)
+	}
+}
+
+func TestParseContentFilter(t *testing.T) {
+	for in, want := range map[string]domain.ContentFilter{"": domain.FilterOff, "Mask": domain.FilterMask, "reject": domain.FilterReject} {
+		if got, err := ParseContentFilter(in); err != nil || got != want {
+			t.Errorf("ParseContentFilter(%q) = %q, %v", in, got, err)
+		}
+	}
+	if _, err := ParseContentFilter("strict"); err == nil {
+		t.Error("ParseContentFilter(strict) should fail")
+	}
+}
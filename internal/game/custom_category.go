@@ -0,0 +1,163 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"impostor/internal/domain"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits for categories uploaded by lobby leaders.
+const (
+	MaxCustomCategories = 5
+	MaxCustomPairs      = 100
+	MinCustomPairs      = 1
+	MaxCustomWordLength = 40
+	MaxCustomTextLength = 200 // Hints and descriptions
+)
+
+var ErrTooManyCustomCategories = errors.New("too many custom categories")
+
+// ValidateCustomCategory checks a leader-supplied category and returns a cleaned-up copy
+// (surrounding whitespace trimmed). Names and IDs of dictionary categories are taken.
+func ValidateCustomCategory(cat domain.Category) (domain.Category, error) {
+	name := strings.TrimSpace(cat.Name)
+	if name == "" {
+		return cat, errors.New("category name is required")
+	}
+	if utf8.RuneCountInString(name) > MaxCustomWordLength {
+		return cat, fmt.Errorf("category name is longer than %d characters", MaxCustomWordLength)
+	}
+	if reservedCategoryName(name) {
+		return cat, fmt.Errorf("category name %q is reserved", name)
+	}
+	if len(cat.Pairs) < MinCustomPairs || len(cat.Pairs) > MaxCustomPairs {
+		return cat, fmt.Errorf("a category needs between %d and %d word pairs", MinCustomPairs, MaxCustomPairs)
+	}
+
+	cleaned := domain.Category{Name: name, Pairs: make([]domain.WordPair, 0, len(cat.Pairs))}
+	seen := make(map[string]bool, len(cat.Pairs))
+	for i, p := range cat.Pairs {
+		real, trap := strings.TrimSpace(p.Real), strings.TrimSpace(p.Trap)
+		if real == "" || trap == "" {
+			return cat, fmt.Errorf("pair %d has an empty word", i+1)
+		}
+		if utf8.RuneCountInString(real) > MaxCustomWordLength || utf8.RuneCountInString(trap) > MaxCustomWordLength {
+			return cat, fmt.Errorf("pair %d has a word longer than %d characters", i+1, MaxCustomWordLength)
+		}
+		if strings.EqualFold(real, trap) {
+			return cat, fmt.Errorf("pair %d uses the same word twice (%q)", i+1, real)
+		}
+		key := strings.ToLower(real) + "\x00" + strings.ToLower(trap)
+		if seen[key] {
+			return cat, fmt.Errorf("pair %d is duplicated (%s/%s)", i+1, real, trap)
+		}
+		seen[key] = true
+		if p.Difficulty != "" && !validDifficulty(p.Difficulty) {
+			return cat, fmt.Errorf("pair %d: %w", i+1, ErrInvalidDifficulty)
+		}
+		hint, description := strings.TrimSpace(p.Hint), strings.TrimSpace(p.Description)
+		if utf8.RuneCountInString(hint) > MaxCustomTextLength || utf8.RuneCountInString(description) > MaxCustomTextLength {
+			return cat, fmt.Errorf("pair %d has a hint or description longer than %d characters", i+1, MaxCustomTextLength)
+		}
+
+		cleaned.Pairs = append(cleaned.Pairs, domain.WordPair{
+			Real:        real,
+			Trap:        trap,
+			Difficulty:  p.Difficulty,
+			Hint:        hint,
+			Description: description,
+			Tags:        p.Tags,
+		})
+	}
+	categories := []domain.Category{cleaned}
+	assignIDs(categories)
+	// It would shadow the dictionary category in the lobby, and be listed twice
+	if dictionaryCategoryExists(name, categories[0].ID) {
+		return cat, fmt.Errorf("category %q already exists in the dictionary", name)
+	}
+	return categories[0], nil
+}
+
+// SetCustomCategory validates and stores a category for this lobby only, on behalf of the leader.
+// Uploading a category with an existing name replaces it.
+func (l *Lobby) SetCustomCategory(requesterID string, cat domain.Category) error {
+	cleaned, err := ValidateCustomCategory(cat)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if p, ok := l.Players[requesterID]; !ok || !p.IsLeader {
+		return ErrNotLeader
+	}
+	if l.CustomCategories == nil {
+		l.CustomCategories = make(map[string]domain.Category)
+	}
+	if _, replacing := l.CustomCategories[cleaned.Name]; !replacing && len(l.CustomCategories) >= MaxCustomCategories {
+		return ErrTooManyCustomCategories
+	}
+
+	l.CustomCategories[cleaned.Name] = cleaned
+	l.touch()
+
+	msg := map[string]interface{}{
+		"type":       "CUSTOM_CATEGORIES",
+		"categories": l.customCategoryNames(),
+	}
+	l.broadcastInternal(msg)
+	return nil
+}
+
+// CustomCategoryNames returns the names of the categories uploaded to this lobby, sorted.
+func (l *Lobby) CustomCategoryNames() []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.customCategoryNames()
+}
+
+// CustomCategoryRefs is CustomCategoryNames with the category IDs.
+func (l *Lobby) CustomCategoryRefs() []CategoryRef {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	refs := make([]CategoryRef, 0, len(l.CustomCategories))
+	for _, name := range l.customCategoryNames() {
+		refs = append(refs, CategoryRef{ID: l.CustomCategories[name].ID, Name: name})
+	}
+	return refs
+}
+
+func (l *Lobby) customCategoryNames() []string {
+	names := make([]string, 0, len(l.CustomCategories))
+	for name := range l.CustomCategories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveCategory finds a category for this lobby by name or ID: custom categories first,
+// then the shared dictionary (see the package-level ResolveCategory).
+func (l *Lobby) ResolveCategory(ref string, language string) domain.Category {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.resolveCategory(ref, language)
+}
+
+// resolveCategory is the lock-free variant of ResolveCategory. Caller must hold the lock.
+func (l *Lobby) resolveCategory(ref string, language string) domain.Category {
+	if custom, ok := l.CustomCategories[ref]; ok {
+		return custom
+	}
+	for _, custom := range l.CustomCategories {
+		if custom.ID == ref {
+			return custom
+		}
+	}
+	return ResolveCategory(ref, language)
+}
@@ -0,0 +1,72 @@
+package game
+
+import (
+	"errors"
+	"impostor/internal/domain"
+	"strings"
+	"testing"
+)
+
+func TestValidateCustomCategory(t *testing.T) {
+	valid := []domain.WordPair{{Real: " Jira ", Trap: "Trello"}}
+
+	tests := []struct {
+		name    string
+		cat     domain.Category
+		wantErr bool
+	}{
+		{"valid", domain.Category{Name: " Office ", Pairs: valid}, false},
+		{"empty name", domain.Category{Name: " ", Pairs: valid}, true},
+		{"reserved name", domain.Category{Name: InfiniteCategory, Pairs: valid}, true},
+		{"reserved theme name", domain.Category{Name: InfiniteCategory + ": Office", Pairs: valid}, true},
+		{"reserved community name", domain.Category{Name: CommunityCategory, Pairs: valid}, true},
+		{"dictionary name", domain.Category{Name: "animals", Pairs: valid}, true},
+		{"dictionary name in another language", domain.Category{Name: "Animales", Pairs: valid}, true},
+		{"no pairs", domain.Category{Name: "Office"}, true},
+		{"empty word", domain.Category{Name: "Office", Pairs: []domain.WordPair{{Real: "Jira", Trap: ""}}}, true},
+		{"real equals trap", domain.Category{Name: "Office", Pairs: []domain.WordPair{{Real: "Jira", Trap: "jira"}}}, true},
+		{"duplicate pair", domain.Category{Name: "Office", Pairs: []domain.WordPair{{Real: "A", Trap: "B"}, {Real: "a", Trap: "b"}}}, true},
+		{"word too long", domain.Category{Name: "Office", Pairs: []domain.WordPair{{Real: strings.Repeat("x", 41), Trap: "B"}}}, true},
+		{"hint too long", domain.Category{Name: "Office", Pairs: []domain.WordPair{{Real: "A", Trap: "B", Hint: strings.Repeat("x", MaxCustomTextLength+1)}}}, true},
+		{"too many pairs", domain.Category{Name: "Office", Pairs: make([]domain.WordPair, MaxCustomPairs+1)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateCustomCategory(tt.cat)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateCustomCategory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (got.Name != "Office" || got.Pairs[0].Real != "Jira") {
+				t.Errorf("ValidateCustomCategory() did not trim: %+v", got)
+			}
+		})
+	}
+}
+
+func TestLobbyCustomCategory(t *testing.T) {
+	h := NewHub()
+	mine, _ := h.CreateLobby("mine", nil)
+	other, _ := h.CreateLobby("other", nil)
+	mine.AddPlayerSafe(&domain.Player{ID: "leader", Name: "Ana"})
+	mine.AddPlayerSafe(&domain.Player{ID: "guest", Name: "Bob"})
+
+	cat := domain.Category{Name: "Project", Pairs: []domain.WordPair{{Real: "Phoenix", Trap: "Falcon"}}}
+
+	if err := mine.SetCustomCategory("guest", cat); !errors.Is(err, ErrNotLeader) {
+		t.Errorf("SetCustomCategory() by guest error = %v, want ErrNotLeader", err)
+	}
+	if err := mine.SetCustomCategory("leader", cat); err != nil {
+		t.Fatalf("SetCustomCategory() error = %v", err)
+	}
+
+	if got := mine.ResolveCategory("Project", "en"); got.Pairs[0].Real != "Phoenix" {
+		t.Errorf("lobby should resolve its custom category, got %+v", got)
+	}
+	if got := other.ResolveCategory("Project", "en"); got.Name == "Project" {
+		t.Error("custom category leaked into another lobby")
+	}
+	if got := mine.ResolveCategory("Animals", "en"); got.Name != "Animals" {
+		t.Errorf("dictionary categories should still resolve, got %q", got.Name)
+	}
+}
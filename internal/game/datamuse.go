@@ -1,15 +1,24 @@
 package game
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"impostor/internal/domain"
 	"math/rand"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 )
 
+// DefaultDatamuseURL is the public Datamuse API.
+const DefaultDatamuseURL = "https://api.datamuse.com"
+
+// DefaultDatamuseTimeout bounds each Datamuse request.
+const DefaultDatamuseTimeout = 5 * time.Second
+
 // DatamuseWord represents the JSON response from Datamuse API
 type DatamuseWord struct {
 	Word  string   `json:"word"`
@@ -17,99 +26,197 @@ type DatamuseWord struct {
 	Tags  []string `json:"tags,omitempty"`
 }
 
-var seedWordsEN = []string{
-	"fire", "water", "earth", "wind", "magic", "science", "space", "time",
-	"love", "war", "peace", "king", "queen", "apple", "banana", "car",
-	"house", "dog", "cat", "bird", "fish", "book", "computer", "phone",
-	"music", "art", "happy", "sad", "fast", "slow", "red", "blue",
-	"city", "forest", "mountain", "ocean", "river", "desert", "snow", "rain",
-	"adventure", "mystery", "history", "future", "robot", "alien", "ghost", "vampire",
-	"coffee", "tea", "beer", "wine", "pizza", "burger", "sushi", "cake",
-    "doctor", "teacher", "police", "thief", "judge", "lawyer", "actor", "singer",
+// DatamuseClient builds Infinite pairs from the Datamuse API.
+// Its circuit breaker stops calling the API for a while once it keeps failing.
+type DatamuseClient struct {
+	baseURL string
+	http    *http.Client
+	breaker *CircuitBreaker
+	filter  *DatamuseFilter
+}
+
+// NewDatamuseClient creates a client for the Datamuse API at baseURL (DefaultDatamuseURL when empty),
+// making requests with httpClient (one with DefaultDatamuseTimeout when nil).
+func NewDatamuseClient(baseURL string, httpClient *http.Client) *DatamuseClient {
+	if baseURL == "" {
+		baseURL = DefaultDatamuseURL
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultDatamuseTimeout}
+	}
+	return &DatamuseClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		http:    httpClient,
+		breaker: NewCircuitBreaker(DefaultBreakerThreshold, DefaultBreakerCooldown),
+		filter:  NewDatamuseFilter(DefaultDatamuseFilterConfig()),
+	}
+}
+
+// SetBreaker replaces the circuit breaker of the client.
+func (c *DatamuseClient) SetBreaker(b *CircuitBreaker) {
+	c.breaker = b
 }
 
-var seedWordsES = []string{
-	"fuego", "agua", "tierra", "viento", "magia", "ciencia", "espacio", "tiempo",
-	"amor", "guerra", "paz", "rey", "reina", "manzana", "plátano", "coche",
-	"casa", "perro", "gato", "pájaro", "pez", "libro", "ordenador", "teléfono",
-	"música", "arte", "feliz", "triste", "rápido", "lento", "rojo", "azul",
-	"ciudad", "bosque", "montaña", "océano", "río", "desierto", "nieve", "lluvia",
-	"aventura", "misterio", "historia", "futuro", "robot", "alien", "fantasma", "vampiro",
-	"café", "té", "cerveza", "vino", "pizza", "hamburguesa", "sushi", "pastel",
-    "doctor", "profesor", "policía", "ladrón", "juez", "abogado", "actor", "cantante",
+// SetFilter replaces the filter deciding which related words can become traps and hints.
+func (c *DatamuseClient) SetFilter(f *DatamuseFilter) {
+	c.filter = f
 }
 
-// FetchRandomPairFromAPI attempts to get a related word pair using Datamuse.
+// Rejected returns the number of related words rejected by each check of the filter.
+func (c *DatamuseClient) Rejected() map[string]uint64 {
+	return c.filter.Rejected()
+}
+
+// BreakerStats returns the state of the client's circuit breaker.
+func (c *DatamuseClient) BreakerStats() BreakerStats {
+	return c.breaker.Stats()
+}
+
+var defaultDatamuse = NewDatamuseClient("", nil)
+
+// FetchRandomPairFromAPI is RandomPair on the public Datamuse API.
+func FetchRandomPairFromAPI(ctx context.Context, language string) (domain.WordPair, error) {
+	return defaultDatamuse.RandomPair(ctx, language)
+}
+
+// RandomPair attempts to get a related word pair using Datamuse.
 // It uses a random seed word to find related words.
-// language should be "en" or "es"
-func FetchRandomPairFromAPI(language string) (domain.WordPair, error) {
-	// Select appropriate seed words based on language
-	seedWords := seedWordsEN
-	if language == "es" {
-		seedWords = seedWordsES
+// Languages without a Datamuse vocabulary return an error, so callers fall back to local words,
+// and so does ErrCircuitOpen while the API is considered down.
+func (c *DatamuseClient) RandomPair(ctx context.Context, language string) (domain.WordPair, error) {
+	return c.RandomThemedPair(ctx, language, nil)
+}
+
+// RandomThemedPair is RandomPair with the seed drawn among the words of an Infinite theme,
+// whose topics are passed to Datamuse so the trap stays on theme. A nil theme uses the seed
+// words of the language.
+func (c *DatamuseClient) RandomThemedPair(ctx context.Context, language string, theme *domain.InfiniteTheme) (domain.WordPair, error) {
+	lang := LookupLanguage(language)
+	seeds, topics := lang.SeedWords, []string(nil)
+	if theme != nil {
+		seeds, topics = theme.Seeds, theme.Topics
 	}
-	
+	if !lang.Datamuse || len(seeds) == 0 {
+		return domain.WordPair{}, fmt.Errorf("no Datamuse vocabulary for language %q", language)
+	}
+	if !c.breaker.Allow() {
+		return domain.WordPair{}, ErrCircuitOpen
+	}
+
+	pair, reached, err := c.randomPair(ctx, lang, seeds, topics)
+	switch {
+	case reached:
+		// Datamuse answered: the API is up, even if it had no usable words
+		c.breaker.Record(nil)
+	case ctx.Err() != nil:
+		c.breaker.Record(ctx.Err()) // We gave up, Datamuse may be fine
+	case errors.Is(err, context.DeadlineExceeded):
+		// The HTTP client timeout matches context.DeadlineExceeded too, but it is Datamuse being slow
+		c.breaker.Record(fmt.Errorf("request timed out: %v", err))
+	default:
+		c.breaker.Record(err)
+	}
+	return pair, err
+}
+
+// randomPair does the work of RandomThemedPair. reached reports whether Datamuse answered any request.
+func (c *DatamuseClient) randomPair(ctx context.Context, lang Language, seedWords, topics []string) (pair domain.WordPair, reached bool, err error) {
 	// Try up to 3 different seeds in case one fails
 	maxRetries := 3
-	for attempt := range maxRetries {
+	var lastErr error
+	for range maxRetries {
+		if err := ctx.Err(); err != nil {
+			return domain.WordPair{}, reached, err
+		}
+
 		// 1. Pick a random seed word
 		seed := seedWords[rand.Intn(len(seedWords))]
-		
+
 		// 2. Fetch related words (Triggers/Associations are often good "Impostor" alternatives)
-		url := fmt.Sprintf("https://api.datamuse.com/words?rel_trg=%s&max=20", seed)
+		relation := "rel_trg"
+		difficulty := domain.DifficultyMedium
 		if rand.Float32() > 0.5 {
 			// 50% chance to look for synonyms instead of associations (Harder)
-			url = fmt.Sprintf("https://api.datamuse.com/words?rel_syn=%s&max=20", seed)
-		}
-		
-		// Add vocabulary parameter for Spanish
-		if language == "es" {
-			url += "&v=es"
+			relation = "rel_syn"
+			difficulty = domain.DifficultyHard
 		}
 
-		client := &http.Client{Timeout: 5 * time.Second}
-		resp, err := client.Get(url)
+		results, err := c.related(ctx, relation, seed, lang.DatamuseVocabulary, topics)
 		if err != nil {
-			if attempt == maxRetries-1 {
-				return domain.WordPair{}, err
-			}
+			lastErr = err
 			continue // Try another seed
 		}
-		defer resp.Body.Close()
-
-		var results []DatamuseWord
-		if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
-			if attempt == maxRetries-1 {
-				return domain.WordPair{}, err
-			}
+		reached = true
+		if len(results) == 0 {
+			lastErr = fmt.Errorf("no words related to %q", seed)
 			continue // Try another seed
 		}
 
-		if len(results) == 0 {
-			if attempt == maxRetries-1 {
-				return domain.WordPair{}, fmt.Errorf("no results after %d attempts", maxRetries)
-			}
+		// 3. Drop the seed itself, phrases, forms of the seed, obscure and blocked words
+		total := len(results)
+		if results = c.filter.Apply(lang.Code, seed, results); len(results) == 0 {
+			lastErr = fmt.Errorf("all %d words related to %q were filtered out", total, seed)
 			continue // Try another seed
 		}
 
-		// 3. Pick a random related word as the Trap
+		// 4. Pick a random related word as the Trap
 		trap := results[rand.Intn(len(results))].Word
 
-		// Ensure they are not identical (API sometimes returns the word itself)
-		if strings.EqualFold(seed, trap) {
-			if len(results) > 1 {
-				trap = results[(rand.Intn(len(results)-1) + 1)%len(results)].Word
-			} else {
-				continue // Try another seed
+		// Another association makes a vague hint for Easy hint mode (synonyms would give the word away)
+		hint := ""
+		if difficulty == domain.DifficultyMedium {
+			for _, i := range rand.Perm(len(results)) {
+				if w := results[i].Word; !strings.EqualFold(w, trap) {
+					hint = strings.Title(w)
+					break
+				}
 			}
 		}
 
 		// Capitalize for display
 		return domain.WordPair{
-			Real: strings.Title(seed),
-			Trap: strings.Title(trap),
-		}, nil
+			Real:       strings.Title(seed),
+			Trap:       strings.Title(trap),
+			Difficulty: difficulty,
+			Hint:       hint,
+		}, true, nil
+	}
+
+	return domain.WordPair{}, reached, fmt.Errorf("failed after %d attempts: %w", maxRetries, lastErr)
+}
+
+// related queries the words linked to word by relation ("rel_trg", "rel_syn"...).
+// vocabulary selects a non-English vocabulary, empty for English, and topics skew the results toward a theme.
+func (c *DatamuseClient) related(ctx context.Context, relation, word, vocabulary string, topics []string) ([]DatamuseWord, error) {
+	query := url.Values{}
+	query.Set(relation, word)
+	query.Set("max", "20")
+	if vocabulary != "" {
+		query.Set("v", vocabulary)
+	}
+	if len(topics) > 0 {
+		query.Set("topics", strings.Join(topics, " "))
+	}
+	if c.filter.NeedsFrequency() {
+		query.Set("md", "f")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/words?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("datamuse responded %s", resp.Status)
+	}
+
+	var results []DatamuseWord
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("decoding datamuse response: %w", err)
 	}
-	
-	return domain.WordPair{}, fmt.Errorf("failed after %d attempts", maxRetries)
+	return results, nil
 }
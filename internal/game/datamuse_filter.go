@@ -0,0 +1,237 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DatamuseFilterConfig tunes which Datamuse words may become traps and hints of Infinite pairs.
+// Zero values disable the corresponding check.
+type DatamuseFilterConfig struct {
+	MinScore       int      // Relatedness score below which words are dropped
+	MaxWords       int      // Longest phrase accepted, in words
+	MinFrequency   float64  // Occurrences per million words below which words are too obscure
+	RejectSameStem bool     // Drop plurals and other inflections of the seed ("Dogs" for "Dog")
+	Blocklist      []string // Words never dealt, whatever the language
+}
+
+// DefaultDatamuseFilterConfig keeps single words that aren't a form of the seed.
+func DefaultDatamuseFilterConfig() DatamuseFilterConfig {
+	return DatamuseFilterConfig{
+		MaxWords:       1,
+		RejectSameStem: true,
+	}
+}
+
+// LoadBlocklist reads one word per line, skipping empty lines and # comments.
+func LoadBlocklist(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseBlocklist(f)
+}
+
+func parseBlocklist(r io.Reader) ([]string, error) {
+	var words []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" && !strings.HasPrefix(line, "#") {
+			words = append(words, line)
+		}
+	}
+	return words, scanner.Err()
+}
+
+// filterStage is one check of the pipeline. It returns why w is rejected, or "" to keep it.
+type filterStage struct {
+	name  string
+	check func(seed string, w DatamuseWord) string
+}
+
+// DatamuseFilter runs the words returned by Datamuse through a pipeline of checks.
+// Each rejection is logged with its reason and counted per check, to help tuning the config.
+type DatamuseFilter struct {
+	config DatamuseFilterConfig
+	stages []filterStage
+
+	mu       sync.Mutex
+	rejected map[string]uint64 // Check name -> words rejected
+}
+
+// NewDatamuseFilter builds the pipeline enabled by config.
+func NewDatamuseFilter(config DatamuseFilterConfig) *DatamuseFilter {
+	f := &DatamuseFilter{config: config, rejected: make(map[string]uint64)}
+
+	// The seed itself is always rejected, Datamuse sometimes returns it
+	f.stages = append(f.stages, filterStage{"seed", func(seed string, w DatamuseWord) string {
+		if strings.EqualFold(w.Word, seed) {
+			return "is the seed itself"
+		}
+		return ""
+	}})
+	if config.MaxWords > 0 {
+		f.stages = append(f.stages, filterStage{"words", func(_ string, w DatamuseWord) string {
+			if n := len(strings.Fields(w.Word)); n > config.MaxWords {
+				return fmt.Sprintf("%d words > %d", n, config.MaxWords)
+			}
+			return ""
+		}})
+	}
+	if config.MinScore > 0 {
+		f.stages = append(f.stages, filterStage{"score", func(_ string, w DatamuseWord) string {
+			if w.Score < config.MinScore {
+				return fmt.Sprintf("score %d < %d", w.Score, config.MinScore)
+			}
+			return ""
+		}})
+	}
+	if config.RejectSameStem {
+		f.stages = append(f.stages, filterStage{"stem", func(seed string, w DatamuseWord) string {
+			for _, word := range strings.Fields(w.Word) {
+				if sameStem(word, seed) {
+					return fmt.Sprintf("%q is a form of the seed", word)
+				}
+			}
+			return ""
+		}})
+	}
+	if len(config.Blocklist) > 0 {
+		blocked := make(map[string]bool, len(config.Blocklist))
+		for _, word := range config.Blocklist {
+			blocked[foldWord(word)] = true
+		}
+		f.stages = append(f.stages, filterStage{"blocklist", func(_ string, w DatamuseWord) string {
+			if blocked[foldWord(w.Word)] {
+				return "blocklisted"
+			}
+			for _, word := range strings.Fields(w.Word) {
+				if blocked[foldWord(word)] {
+					return fmt.Sprintf("%q is blocklisted", word)
+				}
+			}
+			return ""
+		}})
+	}
+	if config.MinFrequency > 0 {
+		f.stages = append(f.stages, filterStage{"frequency", func(_ string, w DatamuseWord) string {
+			if freq := w.Frequency(); freq < config.MinFrequency {
+				return fmt.Sprintf("frequency %g < %g per million", freq, config.MinFrequency)
+			}
+			return ""
+		}})
+	}
+	return f
+}
+
+// NeedsFrequency reports whether Datamuse must be asked for word frequencies (md=f).
+func (f *DatamuseFilter) NeedsFrequency() bool {
+	return f.config.MinFrequency > 0
+}
+
+// Apply returns the words that pass every check, in their original order.
+func (f *DatamuseFilter) Apply(language, seed string, words []DatamuseWord) []DatamuseWord {
+	kept := make([]DatamuseWord, 0, len(words))
+	for _, w := range words {
+		if name, reason := f.reject(seed, w); name != "" {
+			log.Printf("Datamuse filter (%s): rejected %q for %q: %s", language, w.Word, seed, reason)
+			f.mu.Lock()
+			f.rejected[name]++
+			f.mu.Unlock()
+			continue
+		}
+		kept = append(kept, w)
+	}
+	return kept
+}
+
+// reject returns the first check rejecting w and its reason, or "" when w passes them all.
+func (f *DatamuseFilter) reject(seed string, w DatamuseWord) (name, reason string) {
+	for _, stage := range f.stages {
+		if reason := stage.check(seed, w); reason != "" {
+			return stage.name, reason
+		}
+	}
+	return "", ""
+}
+
+// Rejected returns the number of words rejected by each check.
+func (f *DatamuseFilter) Rejected() map[string]uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	rejected := make(map[string]uint64, len(f.rejected))
+	for name, n := range f.rejected {
+		rejected[name] = n
+	}
+	return rejected
+}
+
+// Frequency returns the occurrences per million words reported in the "f:" tag (requested with md=f),
+// or 0 when Datamuse didn't report it.
+func (w DatamuseWord) Frequency() float64 {
+	for _, tag := range w.Tags {
+		if v, ok := strings.CutPrefix(tag, "f:"); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return f
+			}
+		}
+	}
+	return 0
+}
+
+// sameStem reports whether a and b look like forms of the same word ("Glasses" and "Glass").
+func sameStem(a, b string) bool {
+	for _, sa := range stems(a) {
+		for _, sb := range stems(b) {
+			if sa == sb {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stems returns the word, folded, and what is left of it without the inflections that make a word
+// look new while naming the same thing: English and Spanish plurals and the -ing/-ed forms.
+// It is deliberately crude, the stems are only compared with each other.
+func stems(word string) []string {
+	w := foldWord(word)
+	forms := []string{w}
+	for _, suffix := range []string{"ies", "es", "s", "ing", "ed"} {
+		if rest, ok := strings.CutSuffix(w, suffix); ok && len(rest) >= 3 {
+			if suffix == "ies" {
+				rest += "y"
+			}
+			forms = append(forms, rest)
+		}
+	}
+	return forms
+}
+
+// foldWord lowercases a word and removes the accents of Latin letters ("Camión" -> "camion").
+func foldWord(word string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(word)) {
+		if folded, ok := accents[r]; ok {
+			r = folded
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+var accents = map[rune]rune{
+	'á': 'a', 'à': 'a', 'â': 'a', 'ä': 'a', 'ã': 'a', 'å': 'a',
+	'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e',
+	'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i',
+	'ó': 'o', 'ò': 'o', 'ô': 'o', 'ö': 'o', 'õ': 'o',
+	'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u',
+	'ñ': 'n', 'ç': 'c',
+}
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"context"
+	"impostor/internal/game/datamusetest"
+	"strings"
+	"testing"
+)
+
+func TestDatamuseFilter(t *testing.T) {
+	f := NewDatamuseFilter(DatamuseFilterConfig{
+		MinScore:       50,
+		MaxWords:       1,
+		MinFrequency:   1,
+		RejectSameStem: true,
+		Blocklist:      []string{"Damn"},
+	})
+	common := []string{"f:12.5"}
+
+	tests := []struct {
+		seed, word string
+		score      int
+		tags       []string
+		rejectedBy string
+	}{
+		{"Dog", "Wolf", 100, common, ""},
+		{"Dog", "dog", 100, common, "seed"},
+		{"Dog", "hot dog", 100, common, "words"},
+		{"Dog", "Puppy", 10, common, "score"},
+		{"Dog", "Dogs", 100, common, "stem"},
+		{"Glass", "Glasses", 100, common, "stem"},
+		{"Berry", "Berries", 100, common, "stem"},
+		{"Camión", "camiones", 100, common, "stem"},
+		{"Dog", "Damn", 100, common, "blocklist"},
+		{"Dog", "DÁMN", 100, common, "blocklist"},
+		{"Dog", "Canid", 100, []string{"f:0.2"}, "frequency"},
+		{"Dog", "Canine", 100, nil, "frequency"},
+	}
+	for _, tt := range tests {
+		name, reason := f.reject(tt.seed, DatamuseWord{Word: tt.word, Score: tt.score, Tags: tt.tags})
+		if name != tt.rejectedBy {
+			t.Errorf("%q for %q rejected by %q (%s), want %q", tt.word, tt.seed, name, reason, tt.rejectedBy)
+		}
+	}
+
+	kept := f.Apply("en", "Dog", []DatamuseWord{
+		{Word: "Wolf", Score: 100, Tags: common},
+		{Word: "Dogs", Score: 100, Tags: common},
+		{Word: "Fox", Score: 10, Tags: common},
+	})
+	if len(kept) != 1 || kept[0].Word != "Wolf" {
+		t.Errorf("Apply() kept %+v, want only Wolf", kept)
+	}
+	if got := f.Rejected(); got["stem"] != 1 || got["score"] != 1 {
+		t.Errorf("Rejected() = %v, want 1 stem and 1 score", got)
+	}
+
+	// Only the seed check is always on
+	if name, _ := NewDatamuseFilter(DatamuseFilterConfig{}).reject("Dog", DatamuseWord{Word: "hot dogs"}); name != "" {
+		t.Errorf("empty config rejected the phrase by %q", name)
+	}
+}
+
+func TestDatamuseClientFilter(t *testing.T) {
+	fake := datamusetest.NewServer(t)
+	fake.SetMode(datamusetest.Noisy)
+	client := NewDatamuseClient(fake.URL, nil)
+	client.SetFilter(NewDatamuseFilter(DatamuseFilterConfig{MinScore: 50, MaxWords: 1, MinFrequency: 1, RejectSameStem: true}))
+
+	for range 10 {
+		pair, err := client.RandomPair(context.Background(), "en")
+		if err != nil {
+			t.Fatalf("RandomPair() error = %v", err)
+		}
+		// Only "<word>-hint" survives the filter
+		if pair.Trap != pair.Real+"-Hint" || pair.Hint != "" {
+			t.Errorf("RandomPair() = %+v, want the only unfiltered word as trap", pair)
+		}
+	}
+	if q := fake.Requests()[0]; q.Get("md") != "f" {
+		t.Errorf("query %v doesn't ask for frequencies", q)
+	}
+
+	rejected := client.Rejected()
+	for _, check := range []string{"seed", "words", "stem", "frequency", "score"} {
+		if rejected[check] != 10 {
+			t.Errorf("Rejected()[%s] = %d, want 10 in %v", check, rejected[check], rejected)
+		}
+	}
+}
+
+func TestParseBlocklist(t *testing.T) {
+	words, err := parseBlocklist(strings.NewReader("# Comment\n\n damn \nHeck\n"))
+	if err != nil || strings.Join(words, ",") != "damn,Heck" {
+		t.Errorf("parseBlocklist() = %q, %v", words, err)
+	}
+}
@@ -0,0 +1,229 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"impostor/internal/domain"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultDatamusePoolSize is the number of Infinite pairs kept ready per language.
+const DefaultDatamusePoolSize = 10
+
+// maxFillFailures stops a refill after that many Datamuse errors in a row; the next Draw starts another one.
+const maxFillFailures = 3
+
+// datamuseFetch fetches one pair of a language, from the seeds of theme when not nil.
+type datamuseFetch func(ctx context.Context, language string, theme *domain.InfiniteTheme) (domain.WordPair, error)
+
+// DatamusePool is the WordProvider of the ✨ Infinite category. It keeps a pool of pairs per language
+// and Infinite theme, refilled from Datamuse in the background, so a game start never waits on the network.
+type DatamusePool struct {
+	size   int
+	fetch  datamuseFetch
+	client *DatamuseClient // For Health, nil in tests
+
+	mu      sync.Mutex
+	ctx     context.Context              // Refills stop when it is done, see Start
+	pools   map[string][]domain.WordPair // Pool key (see poolKey) -> pairs ready to be dealt
+	filling map[string]bool              // Pool keys with a refill in progress
+
+	misses atomic.Uint64 // Draws that found no pair ready
+}
+
+// NewDatamusePool creates a pool keeping up to size pairs per language and theme, fetched with client.
+func NewDatamusePool(size int, client *DatamuseClient) *DatamusePool {
+	p := newDatamusePool(size, client.RandomThemedPair)
+	p.client = client
+	return p
+}
+
+func newDatamusePool(size int, fetch datamuseFetch) *DatamusePool {
+	if size <= 0 {
+		size = DefaultDatamusePoolSize
+	}
+	return &DatamusePool{
+		size:    size,
+		fetch:   fetch,
+		ctx:     context.Background(),
+		pools:   make(map[string][]domain.WordPair),
+		filling: make(map[string]bool),
+	}
+}
+
+// Start prefetches every language with a Datamuse vocabulary. Refills, including the ones started later
+// by Draw, are canceled when ctx is done.
+func (p *DatamusePool) Start(ctx context.Context) {
+	p.mu.Lock()
+	p.ctx = ctx
+	p.mu.Unlock()
+	p.Prefetch()
+}
+
+// Draw takes a pair from the pool of the language and theme, preferring the requested difficulty, and
+// starts a refill. It returns ErrNoWords when the pool is empty or the language has no Datamuse vocabulary.
+// The refill outlives ctx: it runs until the pool is full or the context given to Start is done.
+func (p *DatamusePool) Draw(ctx context.Context, req WordRequest) (domain.WordPair, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.WordPair{}, err
+	}
+	lang := LookupLanguage(req.Language)
+	if !lang.Datamuse {
+		return domain.WordPair{}, ErrNoWords
+	}
+
+	key := poolKey(lang.Code, req.Theme)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	defer p.refill(lang.Code, req.Theme)
+
+	pool := p.pools[key]
+	if len(pool) == 0 {
+		p.misses.Add(1)
+		return domain.WordPair{}, ErrNoWords
+	}
+	i := len(pool) - 1
+	if req.Difficulty != "" {
+		for j := len(pool) - 1; j >= 0; j-- {
+			if DifficultyOf(pool[j]) == req.Difficulty {
+				i = j
+				break
+			}
+		}
+	}
+	pair := pool[i]
+	p.pools[key] = append(pool[:i], pool[i+1:]...)
+	return pair, nil
+}
+
+// Serves reports whether the language has a Datamuse vocabulary.
+func (p *DatamusePool) Serves(language string) bool {
+	return LookupLanguage(language).Datamuse
+}
+
+// Prefetch starts filling the pools of the given languages, so the first Infinite games don't fall back.
+// Without languages, every language with a Datamuse vocabulary is prefetched. Themes are not: the pool
+// of a theme starts filling on its first draw, so Datamuse is only queried for the themes people play.
+func (p *DatamusePool) Prefetch(languages ...string) {
+	if len(languages) == 0 {
+		for _, lang := range AvailableLanguages() {
+			languages = append(languages, lang.Code)
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, code := range languages {
+		lang := LookupLanguage(code)
+		if !lang.Datamuse {
+			continue
+		}
+		p.refill(lang.Code, nil)
+	}
+}
+
+// Len returns the number of pairs ready for a language, for the plain ✨ Infinite.
+func (p *DatamusePool) Len(language string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.pools[poolKey(LookupLanguage(language).Code, nil)])
+}
+
+// poolKey identifies the pool of a language and theme: "en", or "en/animals" for a theme.
+func poolKey(code string, theme *domain.InfiniteTheme) string {
+	if theme == nil {
+		return code
+	}
+	return code + "/" + theme.ID
+}
+
+// refill starts filling the pool of a language and theme unless it is full or already being filled.
+// Caller must hold the lock.
+func (p *DatamusePool) refill(code string, theme *domain.InfiniteTheme) {
+	key := poolKey(code, theme)
+	if p.filling[key] || len(p.pools[key]) >= p.size || p.ctx.Err() != nil {
+		return
+	}
+	p.filling[key] = true
+	go p.fill(p.ctx, code, theme)
+}
+
+func (p *DatamusePool) fill(ctx context.Context, code string, theme *domain.InfiniteTheme) {
+	key := poolKey(code, theme)
+	defer func() {
+		p.mu.Lock()
+		p.filling[key] = false
+		p.mu.Unlock()
+	}()
+
+	failures := 0
+	for failures < maxFillFailures {
+		pair, err := p.fetch(ctx, code, theme)
+		if ctx.Err() != nil || errors.Is(err, ErrCircuitOpen) {
+			return // The next Draw after the cooldown tries again
+		}
+		if err != nil {
+			failures++
+			log.Printf("Datamuse pool (%s): %v", key, err)
+			continue
+		}
+
+		p.mu.Lock()
+		if containsPair(p.pools[key], pair) {
+			failures++ // Small vocabularies may keep repeating themselves
+		} else {
+			p.pools[key] = append(p.pools[key], pair)
+			failures = 0
+		}
+		full := len(p.pools[key]) >= p.size
+		p.mu.Unlock()
+		if full {
+			return
+		}
+	}
+}
+
+// DatamuseHealth reports whether the ✨ Infinite category is served by Datamuse or falling back
+// to local words.
+type DatamuseHealth struct {
+	Status  string         `json:"status"` // "ok", or "fallback" while the circuit breaker is not closed
+	Breaker *BreakerStats  `json:"breaker,omitempty"`
+	Pool    map[string]int `json:"pool"`   // Pairs ready per language ("en") and theme ("en/animals")
+	Misses  uint64         `json:"misses"` // Infinite draws that found no pair ready (served offline or by local words)
+
+	Rejected map[string]uint64 `json:"rejected,omitempty"` // Related words dropped by each filter check
+}
+
+// Health returns the state of the pool and of the Datamuse client behind it.
+func (p *DatamusePool) Health() DatamuseHealth {
+	h := DatamuseHealth{Status: "ok", Pool: make(map[string]int), Misses: p.misses.Load()}
+
+	p.mu.Lock()
+	for code, pool := range p.pools {
+		h.Pool[code] = len(pool)
+	}
+	p.mu.Unlock()
+
+	if p.client != nil {
+		stats := p.client.BreakerStats()
+		h.Breaker = &stats
+		h.Rejected = p.client.Rejected()
+		if stats.State != BreakerClosed {
+			h.Status = "fallback"
+		}
+	}
+	return h
+}
+
+// containsPair reports whether a pair with the same real word is already in pairs.
+func containsPair(pairs []domain.WordPair, pair domain.WordPair) bool {
+	for _, p := range pairs {
+		if strings.EqualFold(p.Real, pair.Real) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"impostor/internal/domain"
+	"impostor/internal/game/datamusetest"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDatamuseRandomPair(t *testing.T) {
+	fake := datamusetest.NewServer(t)
+	client := NewDatamuseClient(fake.URL, nil)
+
+	for range 20 {
+		pair, err := client.RandomPair(context.Background(), "en")
+		if err != nil {
+			t.Fatalf("RandomPair() error = %v", err)
+		}
+		if pair.Real == "" || pair.Trap == "" || strings.EqualFold(pair.Real, pair.Trap) {
+			t.Fatalf("RandomPair() = %+v", pair)
+		}
+		if !strings.HasPrefix(pair.Trap, pair.Real+"-") {
+			t.Errorf("trap %q is not related to %q", pair.Trap, pair.Real)
+		}
+		switch pair.Difficulty {
+		case domain.DifficultyMedium:
+			if pair.Hint == "" || pair.Hint == pair.Trap {
+				t.Errorf("association pair %+v should have a hint other than the trap", pair)
+			}
+		case domain.DifficultyHard:
+			if pair.Hint != "" {
+				t.Errorf("synonym pair %+v should have no hint", pair)
+			}
+		default:
+			t.Errorf("unexpected difficulty in %+v", pair)
+		}
+	}
+
+	for _, q := range fake.Requests() {
+		if q.Get("rel_trg") == "" && q.Get("rel_syn") == "" {
+			t.Errorf("request without relation: %v", q)
+		}
+		if q.Has("v") {
+			t.Errorf("English request with a vocabulary: %v", q)
+		}
+	}
+}
+
+func TestDatamuseVocabulary(t *testing.T) {
+	fake := datamusetest.NewServer(t)
+	if _, err := NewDatamuseClient(fake.URL, nil).RandomPair(context.Background(), "es"); err != nil {
+		t.Fatal(err)
+	}
+	if got := fake.Requests()[0].Get("v"); got != "es" {
+		t.Errorf("vocabulary = %q, want es", got)
+	}
+
+	// Without a vocabulary, Datamuse isn't even called
+	if _, err := NewDatamuseClient(fake.URL, nil).RandomPair(context.Background(), "fr"); err == nil {
+		t.Error("RandomPair(fr) should fail")
+	}
+	if n := len(fake.Requests()); n != 1 {
+		t.Errorf("%d requests, want 1", n)
+	}
+}
+
+func TestDatamuseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		mode  datamusetest.Mode
+		delay time.Duration
+		want  string
+	}{
+		{"empty results", datamusetest.Empty, 0, "no words related"},
+		{"malformed JSON", datamusetest.Malformed, 0, "decoding"},
+		{"server error", datamusetest.Failing, 0, "500"},
+		{"seed as its own trap", datamusetest.EchoSeed, 0, "filtered out"},
+		{"timeout", datamusetest.Related, time.Second, "Timeout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := datamusetest.NewServer(t)
+			fake.SetMode(tt.mode)
+			fake.SetDelay(tt.delay)
+			client := NewDatamuseClient(fake.URL, &http.Client{Timeout: 50 * time.Millisecond})
+
+			pair, err := client.RandomPair(context.Background(), "en")
+			if err == nil {
+				t.Fatalf("RandomPair() = %+v, want an error", pair)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("RandomPair() error = %v, want it to mention %q", err, tt.want)
+			}
+			if n := len(fake.Requests()); n != 3 {
+				t.Errorf("%d requests, want 3 attempts", n)
+			}
+		})
+	}
+}
+
+func TestDatamusePoolWithFakeServer(t *testing.T) {
+	fake := datamusetest.NewServer(t)
+	pool := NewDatamusePool(2, NewDatamuseClient(fake.URL, nil))
+
+	pool.Prefetch("en")
+	waitForPool(t, pool, "en", 2)
+	if pair, err := pool.Draw(context.Background(), WordRequest{Language: "en"}); err != nil || pair.Real == "" {
+		t.Errorf("Draw() = %+v, %v", pair, err)
+	}
+}
+
+func TestDatamuseCircuitBreaker(t *testing.T) {
+	fake := datamusetest.NewServer(t)
+	fake.SetMode(datamusetest.Failing)
+	client := NewDatamuseClient(fake.URL, nil)
+	client.SetBreaker(NewCircuitBreaker(2, time.Hour))
+
+	for range 2 {
+		if _, err := client.RandomPair(context.Background(), "en"); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("RandomPair() error = %v, want the Datamuse error", err)
+		}
+	}
+	requests := len(fake.Requests())
+
+	// Datamuse is skipped while the breaker is open
+	fake.SetMode(datamusetest.Related)
+	if _, err := client.RandomPair(context.Background(), "en"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("RandomPair() error = %v, want ErrCircuitOpen", err)
+	}
+	if len(fake.Requests()) != requests {
+		t.Error("Datamuse was called with the breaker open")
+	}
+	if state := client.BreakerStats().State; state != BreakerOpen {
+		t.Errorf("breaker state = %s, want open", state)
+	}
+}
+
+func TestDatamuseAnsweringDoesNotTripBreaker(t *testing.T) {
+	fake := datamusetest.NewServer(t)
+	fake.SetMode(datamusetest.Empty)
+	client := NewDatamuseClient(fake.URL, nil)
+	client.SetBreaker(NewCircuitBreaker(1, time.Hour))
+
+	client.RandomPair(context.Background(), "en")
+	if state := client.BreakerStats().State; state != BreakerClosed {
+		t.Errorf("breaker state = %s after empty results, want closed", state)
+	}
+}
+
+func TestDatamuseContext(t *testing.T) {
+	fake := datamusetest.NewServer(t)
+	fake.SetDelay(time.Second)
+	client := NewDatamuseClient(fake.URL, nil)
+	client.SetBreaker(NewCircuitBreaker(1, time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.RandomPair(ctx, "en"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("RandomPair() error = %v, want the context error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("RandomPair() took %v after its context expired", elapsed)
+	}
+	if n := len(fake.Requests()); n != 1 {
+		t.Errorf("%d requests, want no retries once the context is done", n)
+	}
+	if state := client.BreakerStats().State; state != BreakerClosed {
+		t.Errorf("breaker state = %s after the caller gave up, want closed", state)
+	}
+}
+
+func TestDatamuseTimeoutTripsBreaker(t *testing.T) {
+	fake := datamusetest.NewServer(t)
+	fake.SetDelay(time.Second)
+	client := NewDatamuseClient(fake.URL, &http.Client{Timeout: 50 * time.Millisecond})
+	client.SetBreaker(NewCircuitBreaker(1, time.Hour))
+
+	client.RandomPair(context.Background(), "en")
+	if state := client.BreakerStats().State; state != BreakerOpen {
+		t.Errorf("breaker state = %s after Datamuse timed out, want open", state)
+	}
+}
+
+func TestDatamusePoolHealth(t *testing.T) {
+	fake := datamusetest.NewServer(t)
+	fake.SetMode(datamusetest.Failing)
+	client := NewDatamuseClient(fake.URL, nil)
+	client.SetBreaker(NewCircuitBreaker(1, time.Hour))
+	pool := NewDatamusePool(2, client)
+
+	if h := pool.Health(); h.Status != "ok" {
+		t.Errorf("Health() before any failure = %+v", h)
+	}
+
+	pool.Prefetch("en")
+	deadline := time.Now().Add(2 * time.Second)
+	for client.BreakerStats().State != BreakerOpen && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if _, err := pool.Draw(context.Background(), WordRequest{Language: "en"}); !errors.Is(err, ErrNoWords) {
+		t.Fatalf("Draw() error = %v, want ErrNoWords", err)
+	}
+
+	h := pool.Health()
+	if h.Status != "fallback" || h.Breaker == nil || h.Breaker.State != BreakerOpen || h.Misses != 1 {
+		t.Errorf("Health() = %+v, want fallback with an open breaker and 1 miss", h)
+	}
+}
+
+func TestDatamusePoolStopsWithContext(t *testing.T) {
+	var calls atomic.Int32
+	pool := newDatamusePool(2, countingFetch(&calls))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	pool.Start(ctx)
+	pool.Draw(context.Background(), WordRequest{Language: "en"})
+	time.Sleep(20 * time.Millisecond)
+	if calls.Load() != 0 {
+		t.Errorf("Datamuse was called %d times after the pool was stopped", calls.Load())
+	}
+}
@@ -0,0 +1,134 @@
+// Package datamusetest provides a fake Datamuse API for tests of the ✨ Infinite category.
+package datamusetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Mode selects how the fake answers.
+type Mode int
+
+const (
+	Related   Mode = iota // Two words related to the queried one: "<word>-trap" and "<word>-hint"
+	Empty                 // No related words
+	Malformed             // A body that isn't JSON
+	EchoSeed              // Only the queried word itself, as Datamuse sometimes does
+	Failing               // HTTP 500
+	Noisy                 // Words the default filter rejects (the word, its plural, a phrase), then Related
+)
+
+// Frequency is the frequency reported by the fake for words other than "<word>-rare", with md=f.
+const Frequency = 10
+
+// Server is a fake Datamuse API, closed when the test ends.
+type Server struct {
+	URL string
+
+	mu       sync.Mutex
+	mode     Mode
+	delay    time.Duration
+	requests []url.Values
+}
+
+// NewServer starts a fake Datamuse API answering in Related mode.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+	s := &Server{}
+	ts := httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(ts.Close)
+	s.URL = ts.URL
+	return s
+}
+
+// SetMode changes how the following requests are answered.
+func (s *Server) SetMode(m Mode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mode = m
+}
+
+// SetDelay makes the following requests wait d before answering, to trigger client timeouts.
+func (s *Server) SetDelay(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delay = d
+}
+
+// Requests returns the query of every request received so far.
+func (s *Server) Requests() []url.Values {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]url.Values(nil), s.requests...)
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	mode, delay := s.mode, s.delay
+	s.requests = append(s.requests, r.URL.Query())
+	s.mu.Unlock()
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if r.URL.Path != "/words" {
+		http.NotFound(w, r)
+		return
+	}
+
+	word := r.URL.Query().Get("rel_trg")
+	if word == "" {
+		word = r.URL.Query().Get("rel_syn")
+	}
+
+	type result struct {
+		Word  string   `json:"word"`
+		Score int      `json:"score"`
+		Tags  []string `json:"tags,omitempty"`
+	}
+	var results []result
+	switch mode {
+	case Malformed:
+		w.Write([]byte(`[{"word": `))
+		return
+	case Failing:
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	case EchoSeed:
+		results = []result{{Word: word, Score: 100}}
+	case Empty:
+		results = []result{}
+	case Noisy:
+		results = []result{
+			{Word: word, Score: 100},
+			{Word: word + "s", Score: 100},
+			{Word: "big " + word, Score: 100},
+			{Word: word + "-rare", Score: 100},
+			{Word: word + "-trap", Score: 10},
+			{Word: word + "-hint", Score: 90},
+		}
+	default:
+		results = []result{{Word: word + "-trap", Score: 100}, {Word: word + "-hint", Score: 90}}
+	}
+	if r.URL.Query().Get("md") == "f" {
+		for i := range results {
+			if results[i].Word == word+"-rare" {
+				results[i].Tags = []string{"f:0.01"}
+			} else {
+				results[i].Tags = []string{fmt.Sprintf("f:%d", Frequency)}
+			}
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
@@ -0,0 +1,65 @@
+package game
+
+import (
+	"impostor/internal/domain"
+	"math/rand"
+	"strings"
+)
+
+// Deck deals the pairs of a category like a shuffle bag: in random order and without repeats,
+// reshuffling only once every pair has been dealt.
+// Dealt pairs are remembered by real word rather than by position, so a deck stays valid
+// when the dictionary is reloaded or edited.
+type Deck struct {
+	dealt map[string]bool // Lowercased real words
+}
+
+// NewDeck creates a deck where the given real words count as already dealt.
+func NewDeck(dealt ...string) *Deck {
+	d := &Deck{dealt: make(map[string]bool, len(dealt))}
+	for _, word := range dealt {
+		d.dealt[deckKey(word)] = true
+	}
+	return d
+}
+
+// Draw deals a random pair that hasn't been dealt yet. When none is left the deck is reshuffled first,
+// which is reported by reshuffled. pairs must not be empty.
+func (d *Deck) Draw(pairs []domain.WordPair) (pair domain.WordPair, reshuffled bool) {
+	remaining := make([]domain.WordPair, 0, len(pairs))
+	for _, p := range pairs {
+		if !d.dealt[deckKey(p.Real)] {
+			remaining = append(remaining, p)
+		}
+	}
+	if len(remaining) == 0 {
+		for _, p := range pairs {
+			delete(d.dealt, deckKey(p.Real))
+		}
+		remaining = pairs
+		reshuffled = true
+	}
+
+	pair = remaining[rand.Intn(len(remaining))]
+	d.dealt[deckKey(pair.Real)] = true
+	return pair, reshuffled
+}
+
+func deckKey(word string) string {
+	return strings.ToLower(strings.TrimSpace(word))
+}
+
+// deck returns the lobby's deck for a category, creating it on first use.
+// Decks live as long as the lobby, so pairs don't repeat across ResetGame. Caller must hold the lock.
+func (l *Lobby) deck(category, language string) *Deck {
+	if l.decks == nil {
+		l.decks = make(map[string]*Deck)
+	}
+	key := language + "/" + category
+	d, ok := l.decks[key]
+	if !ok {
+		d = NewDeck()
+		l.decks[key] = d
+	}
+	return d
+}
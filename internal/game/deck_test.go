@@ -0,0 +1,63 @@
+package game
+
+import (
+	"context"
+	"impostor/internal/domain"
+	"testing"
+)
+
+func TestDeckDealsEveryPairOnce(t *testing.T) {
+	pairs := []domain.WordPair{
+		{Real: "Coffee", Trap: "Tea"},
+		{Real: "Sun", Trap: "Moon"},
+		{Real: "Dog", Trap: "Wolf"},
+		{Real: "Pen", Trap: "Pencil"},
+	}
+
+	d := NewDeck()
+	seen := make(map[string]bool)
+	for range pairs {
+		pair, reshuffled := d.Draw(pairs)
+		if reshuffled {
+			t.Fatal("deck reshuffled before running out")
+		}
+		if seen[pair.Real] {
+			t.Fatalf("pair %q dealt twice", pair.Real)
+		}
+		seen[pair.Real] = true
+	}
+
+	if _, reshuffled := d.Draw(pairs); !reshuffled {
+		t.Error("deck should reshuffle once every pair has been dealt")
+	}
+}
+
+func TestDeckExclusions(t *testing.T) {
+	pairs := []domain.WordPair{{Real: "Coffee", Trap: "Tea"}, {Real: "Sun", Trap: "Moon"}}
+
+	pair, _ := NewDeck("coffee ").Draw(pairs)
+	if pair.Real != "Sun" {
+		t.Errorf("excluded pair was dealt: %v", pair)
+	}
+}
+
+func TestLobbyDeckSurvivesReset(t *testing.T) {
+	h := NewHub()
+	l, _ := h.CreateLobby("deck", nil)
+	for _, id := range []string{"p1", "p2", "p3"} {
+		l.AddPlayerSafe(&domain.Player{ID: id, Name: id})
+	}
+	cat := GetCategoryByName("Animals", "en")
+
+	seen := make(map[string]bool)
+	for range cat.Pairs {
+		if err := l.StartGame(context.Background(), cat, domain.ModeHard); err != nil {
+			t.Fatalf("StartGame() error = %v", err)
+		}
+		if seen[l.CurrentPair.Real] {
+			t.Fatalf("pair %q repeated before the deck ran out", l.CurrentPair.Real)
+		}
+		seen[l.CurrentPair.Real] = true
+		l.ResetGame()
+	}
+}
@@ -1,309 +1,319 @@
 package game
 
 import (
+	"embed"
 	"impostor/internal/domain"
+	"log"
 	"math/rand"
-	"time"
+	"strings"
+	"sync/atomic"
 )
 
-// DefaultCategories provides the static content for the game (English).
-var DefaultCategories = []domain.Category{
-	{
-		Name: "General",
-		Pairs: []domain.WordPair{
-			{Real: "Hospital", Trap: "Pharmacy"},
-			{Real: "Beach", Trap: "Pool"},
-			{Real: "Cinema", Trap: "Theater"},
-			{Real: "Library", Trap: "Bookstore"},
-			{Real: "Airport", Trap: "Station"},
-			{Real: "Guitar", Trap: "Violin"},
-			{Real: "Coffee", Trap: "Tea"},
-			{Real: "Sun", Trap: "Moon"},
-            {Real: "Chair", Trap: "Stool"},
-            {Real: "Laptop", Trap: "Tablet"},
-            {Real: "Pen", Trap: "Pencil"},
-            {Real: "Facebook", Trap: "Instagram"},
-            {Real: "Google", Trap: "Bing"},
-            {Real: "Marvel", Trap: "DC"},
-            {Real: "Harry Potter", Trap: "Lord of the Rings"},
-            {Real: "Star Wars", Trap: "Star Trek"},
-            {Real: "Minecraft", Trap: "Roblox"},
-            {Real: "Fortnite", Trap: "PUBG"},
-            {Real: "Coca Cola", Trap: "Pepsi"},
-            {Real: "McDonalds", Trap: "Burger King"},
-            {Real: "Nike", Trap: "Adidas"},
-            {Real: "iPhone", Trap: "Samsung"},
-            {Real: "Windows", Trap: "MacOS"},
-            {Real: "Python", Trap: "Java"},
-            {Real: "Gold", Trap: "Silver"},
-            {Real: "Diamond", Trap: "Ruby"},
-            {Real: "Shirt", Trap: "T-Shirt"},
-            {Real: "Shoes", Trap: "Sneakers"},
-            {Real: "Glasses", Trap: "Sunglasses"},
-            {Real: "Watch", Trap: "Bracelet"},
-		},
-	},
-	{
-		Name: "Animals",
-		Pairs: []domain.WordPair{
-			{Real: "Dog", Trap: "Wolf"},
-			{Real: "Cat", Trap: "Tiger"},
-			{Real: "Horse", Trap: "Zebra"},
-			{Real: "Shark", Trap: "Dolphin"},
-			{Real: "Eagle", Trap: "Falcon"},
-			{Real: "Snake", Trap: "Lizard"},
-            {Real: "Lion", Trap: "Cheetah"},
-            {Real: "Bear", Trap: "Panda"},
-            {Real: "Elephant", Trap: "Hippo"},
-            {Real: "Giraffe", Trap: "Camel"},
-            {Real: "Penguin", Trap: "Ostrich"},
-            {Real: "Frog", Trap: "Toad"},
-            {Real: "Bee", Trap: "Wasp"},
-            {Real: "Ant", Trap: "Termite"},
-            {Real: "Spider", Trap: "Scorpion"},
-            {Real: "Butterfly", Trap: "Moth"},
-            {Real: "Whale", Trap: "Orca"},
-            {Real: "Crab", Trap: "Lobster"},
-            {Real: "Octopus", Trap: "Squid"},
-            {Real: "Rat", Trap: "Mouse"},
-            {Real: "Rabbit", Trap: "Hare"},
-            {Real: "Cow", Trap: "Bull"},
-            {Real: "Sheep", Trap: "Goat"},
-            {Real: "Chicken", Trap: "Turkey"},
-            {Real: "Duck", Trap: "Goose"},
-		},
-	},
-	{
-		Name: "Food",
-		Pairs: []domain.WordPair{
-			{Real: "Pizza", Trap: "Burger"},
-			{Real: "Sushi", Trap: "Sashimi"},
-			{Real: "Tacos", Trap: "Burritos"},
-			{Real: "Ice Cream", Trap: "Yogurt"},
-			{Real: "Pasta", Trap: "Noodles"},
-			{Real: "Cake", Trap: "Pie"},
-            {Real: "Bread", Trap: "Toast"},
-            {Real: "Butter", Trap: "Margarine"},
-            {Real: "Cheese", Trap: "Cream"},
-            {Real: "Milk", Trap: "Juice"},
-            {Real: "Water", Trap: "Soda"},
-            {Real: "Beer", Trap: "Wine"},
-            {Real: "Whiskey", Trap: "Vodka"},
-            {Real: "Tomato", Trap: "Potato"},
-            {Real: "Onion", Trap: "Garlic"},
-            {Real: "Apple", Trap: "Pear"},
-            {Real: "Orange", Trap: "Lemon"},
-            {Real: "Banana", Trap: "Plantain"},
-            {Real: "Strawberry", Trap: "Raspberry"},
-            {Real: "Grape", Trap: "Cherry"},
-            {Real: "Chocolate", Trap: "Vanilla"},
-            {Real: "Cookie", Trap: "Biscuit"},
-            {Real: "Sandwich", Trap: "Wrap"},
-            {Real: "Salad", Trap: "Soup"},
-            {Real: "Steak", Trap: "Pork Chop"},
-		},
-	},
-	{
-		Name: "Places",
-		Pairs: []domain.WordPair{
-			{Real: "Paris", Trap: "Rome"},
-			{Real: "New York", Trap: "Chicago"},
-			{Real: "Tokyo", Trap: "Seoul"},
-			{Real: "London", Trap: "Dublin"},
-			{Real: "School", Trap: "University"},
-			{Real: "Gym", Trap: "Park"},
-            {Real: "Kitchen", Trap: "Bathroom"},
-            {Real: "Bedroom", Trap: "Living Room"},
-            {Real: "Hotel", Trap: "Motel"},
-            {Real: "Restaurant", Trap: "Cafe"},
-            {Real: "Bar", Trap: "Club"},
-            {Real: "Library", Trap: "Museum"},
-            {Real: "Zoo", Trap: "Aquarium"},
-            {Real: "Beach", Trap: "Pool"},
-            {Real: "Mountain", Trap: "Hill"},
-            {Real: "Forest", Trap: "Jungle"},
-            {Real: "Desert", Trap: "Canyon"},
-            {Real: "Island", Trap: "Peninsula"},
-            {Real: "Bridge", Trap: "Tunnel"},
-            {Real: "Castle", Trap: "Palace"},
-            {Real: "Pyramid", Trap: "Temple"},
-            {Real: "Spain", Trap: "Italy"},
-            {Real: "USA", Trap: "Canada"},
-            {Real: "China", Trap: "Japan"},
-            {Real: "Brazil", Trap: "Argentina"},
-		},
-	},
+// builtinDictionaries provides the static content for the game.
+// It is used as-is when no dictionary directory is configured, and as fallback
+// for any language missing from that directory.
+//
+//go:embed data/dictionaries
+var builtinDictionaries embed.FS
+
+// DefaultLanguage is used when a language is unknown or not given.
+const DefaultLanguage = "en"
+
+// InfiniteCategory is the special category whose pairs are generated on the fly.
+// Each Infinite theme of a language is another such category, "✨ Infinite: <theme>" with ID "infinite-<theme id>".
+const (
+	InfiniteCategory   = "✨ Infinite"
+	InfiniteCategoryID = "infinite"
+)
+
+// Dictionary holds the word categories and Infinite themes of every language. It is immutable once loaded:
+// reloads build a new Dictionary and swap it in atomically.
+type Dictionary struct {
+	languages map[string][]domain.Category
+	themes    map[string][]domain.InfiniteTheme
+	builtin   map[string]bool // Languages whose themes are the built-in ones, not read from their file
+}
+
+// Categories returns the categories of a language, following its fallback chain (pt-BR -> pt -> en).
+func (d *Dictionary) Categories(language string) []domain.Category {
+	for _, code := range LanguageChain(language) {
+		if categories, ok := d.languages[code]; ok {
+			return categories
+		}
+	}
+	return d.languages[DefaultLanguage]
 }
 
-// DefaultCategoriesES provides Spanish translations
-var DefaultCategoriesES = []domain.Category{
-	{
-		Name: "General",
-		Pairs: []domain.WordPair{
-			{Real: "Hospital", Trap: "Farmacia"},
-			{Real: "Playa", Trap: "Piscina"},
-			{Real: "Cine", Trap: "Teatro"},
-			{Real: "Biblioteca", Trap: "Librería"},
-			{Real: "Aeropuerto", Trap: "Estación"},
-			{Real: "Guitarra", Trap: "Violín"},
-			{Real: "Café", Trap: "Té"},
-			{Real: "Sol", Trap: "Luna"},
-            {Real: "Silla", Trap: "Taburete"},
-            {Real: "Portátil", Trap: "Tableta"},
-            {Real: "Bolígrafo", Trap: "Lápiz"},
-            {Real: "Facebook", Trap: "Instagram"},
-            {Real: "Google", Trap: "Bing"},
-            {Real: "Marvel", Trap: "DC"},
-            {Real: "Harry Potter", Trap: "El Señor de los Anillos"},
-            {Real: "Star Wars", Trap: "Star Trek"},
-            {Real: "Minecraft", Trap: "Roblox"},
-            {Real: "Fortnite", Trap: "PUBG"},
-            {Real: "Coca Cola", Trap: "Pepsi"},
-            {Real: "McDonalds", Trap: "Burger King"},
-            {Real: "Nike", Trap: "Adidas"},
-            {Real: "iPhone", Trap: "Samsung"},
-            {Real: "Windows", Trap: "MacOS"},
-            {Real: "Python", Trap: "Java"},
-            {Real: "Oro", Trap: "Plata"},
-            {Real: "Diamante", Trap: "Rubí"},
-            {Real: "Camisa", Trap: "Camiseta"},
-            {Real: "Zapatos", Trap: "Zapatillas"},
-            {Real: "Gafas", Trap: "Gafas de Sol"},
-            {Real: "Reloj", Trap: "Pulsera"},
-		},
-	},
-	{
-		Name: "Animales",
-		Pairs: []domain.WordPair{
-			{Real: "Perro", Trap: "Lobo"},
-			{Real: "Gato", Trap: "Tigre"},
-			{Real: "Caballo", Trap: "Cebra"},
-			{Real: "Tiburón", Trap: "Delfín"},
-			{Real: "Águila", Trap: "Halcón"},
-			{Real: "Serpiente", Trap: "Lagarto"},
-            {Real: "León", Trap: "Guepardo"},
-            {Real: "Oso", Trap: "Panda"},
-            {Real: "Elefante", Trap: "Hipopótamo"},
-            {Real: "Jirafa", Trap: "Camello"},
-            {Real: "Pingüino", Trap: "Avestruz"},
-            {Real: "Rana", Trap: "Sapo"},
-            {Real: "Abeja", Trap: "Avispa"},
-            {Real: "Hormiga", Trap: "Termita"},
-            {Real: "Araña", Trap: "Escorpión"},
-            {Real: "Mariposa", Trap: "Polilla"},
-            {Real: "Ballena", Trap: "Orca"},
-            {Real: "Cangrejo", Trap: "Langosta"},
-            {Real: "Pulpo", Trap: "Calamar"},
-            {Real: "Rata", Trap: "Ratón"},
-            {Real: "Conejo", Trap: "Liebre"},
-            {Real: "Vaca", Trap: "Toro"},
-            {Real: "Oveja", Trap: "Cabra"},
-            {Real: "Pollo", Trap: "Pavo"},
-            {Real: "Pato", Trap: "Ganso"},
-		},
-	},
-	{
-		Name: "Comida",
-		Pairs: []domain.WordPair{
-			{Real: "Pizza", Trap: "Hamburguesa"},
-			{Real: "Sushi", Trap: "Sashimi"},
-			{Real: "Tacos", Trap: "Burritos"},
-			{Real: "Helado", Trap: "Yogur"},
-			{Real: "Pasta", Trap: "Fideos"},
-			{Real: "Pastel", Trap: "Tarta"},
-            {Real: "Pan", Trap: "Tostada"},
-            {Real: "Mantequilla", Trap: "Margarina"},
-            {Real: "Queso", Trap: "Crema"},
-            {Real: "Leche", Trap: "Zumo"},
-            {Real: "Agua", Trap: "Refresco"},
-            {Real: "Cerveza", Trap: "Vino"},
-            {Real: "Whisky", Trap: "Vodka"},
-            {Real: "Tomate", Trap: "Patata"},
-            {Real: "Cebolla", Trap: "Ajo"},
-            {Real: "Manzana", Trap: "Pera"},
-            {Real: "Naranja", Trap: "Limón"},
-            {Real: "Plátano", Trap: "Plántano Macho"},
-            {Real: "Fresa", Trap: "Frambuesa"},
-            {Real: "Uva", Trap: "Cereza"},
-            {Real: "Chocolate", Trap: "Vainilla"},
-            {Real: "Galleta", Trap: "Bizcocho"},
-            {Real: "Sándwich", Trap: "Wrap"},
-            {Real: "Ensalada", Trap: "Sopa"},
-            {Real: "Filete", Trap: "Chuleta de Cerdo"},
-		},
-	},
-	{
-		Name: "Lugares",
-		Pairs: []domain.WordPair{
-			{Real: "París", Trap: "Roma"},
-			{Real: "Nueva York", Trap: "Chicago"},
-			{Real: "Tokio", Trap: "Seúl"},
-			{Real: "Londres", Trap: "Dublín"},
-			{Real: "Escuela", Trap: "Universidad"},
-			{Real: "Gimnasio", Trap: "Parque"},
-            {Real: "Cocina", Trap: "Baño"},
-            {Real: "Dormitorio", Trap: "Salón"},
-            {Real: "Hotel", Trap: "Motel"},
-            {Real: "Restaurante", Trap: "Cafetería"},
-            {Real: "Bar", Trap: "Club"},
-            {Real: "Biblioteca", Trap: "Museo"},
-            {Real: "Zoológico", Trap: "Acuario"},
-            {Real: "Playa", Trap: "Piscina"},
-            {Real: "Montaña", Trap: "Colina"},
-            {Real: "Bosque", Trap: "Selva"},
-            {Real: "Desierto", Trap: "Cañón"},
-            {Real: "Isla", Trap: "Península"},
-            {Real: "Puente", Trap: "Túnel"},
-            {Real: "Castillo", Trap: "Palacio"},
-            {Real: "Pirámide", Trap: "Templo"},
-            {Real: "España", Trap: "Italia"},
-            {Real: "EE.UU.", Trap: "Canadá"},
-            {Real: "China", Trap: "Japón"},
-            {Real: "Brasil", Trap: "Argentina"},
-		},
-	},
+// Themes returns the Infinite themes of a language. It follows the fallback chain like Categories,
+// but stops at the first language with categories, so French words never get English themes.
+func (d *Dictionary) Themes(language string) []domain.InfiniteTheme {
+	for _, code := range LanguageChain(language) {
+		if themes, ok := d.themes[code]; ok {
+			return themes
+		}
+		if _, ok := d.languages[code]; ok {
+			return nil
+		}
+	}
+	return nil
 }
 
-func GetCategoryByName(name string, language string) domain.Category {
-    if name == "✨ Infinite" {
-        return domain.Category{Name: "✨ Infinite", Pairs: []domain.WordPair{}}
-    }
+// fileThemes returns the themes a language defines in its own dictionary file, nil for the built-in ones.
+func (d *Dictionary) fileThemes(language string) []domain.InfiniteTheme {
+	if d.builtin[language] {
+		return nil
+	}
+	return d.themes[language]
+}
+
+// Lookup returns the categories of a language, without falling back.
+func (d *Dictionary) Lookup(language string) ([]domain.Category, bool) {
+	categories, ok := d.languages[language]
+	return categories, ok
+}
+
+// Languages returns the languages present in the dictionary.
+func (d *Dictionary) Languages() []string {
+	languages := make([]string, 0, len(d.languages))
+	for lang := range d.languages {
+		languages = append(languages, lang)
+	}
+	return languages
+}
+
+var currentDictionary atomic.Pointer[Dictionary]
+
+func init() {
+	d, err := loadBuiltinDictionary()
+	if err != nil {
+		log.Fatalf("Invalid built-in dictionary: %v", err)
+	}
+	currentDictionary.Store(d)
+}
 
-	categories := DefaultCategories
-	if language == "es" {
-		categories = DefaultCategoriesES
+// CurrentDictionary returns the dictionary in use.
+func CurrentDictionary() *Dictionary {
+	return currentDictionary.Load()
+}
+
+// SetDictionary replaces the dictionary in use. Games already started keep their word pair.
+func SetDictionary(d *Dictionary) {
+	currentDictionary.Store(d)
+}
+
+func GetCategoryByName(name string, language string) domain.Category {
+	if name == InfiniteCategory {
+		return infiniteCategory()
+	}
+	if name == CommunityCategory {
+		if cat, ok := communityCategory(language); ok {
+			return cat
+		}
+	}
+	for _, cat := range infiniteThemeCategories(language) {
+		if cat.Name == name {
+			return cat
+		}
 	}
 
+	categories := CurrentDictionary().Categories(language)
+
 	for _, c := range categories {
 		if c.Name == name {
 			return c
 		}
 	}
-	// Fallback to General if not found
+	// Fallback to General (the first category) if not found
 	return categories[0]
 }
 
-func GetAllCategoryNames(language string) []string {
-	categories := DefaultCategories
-	if language == "es" {
-		categories = DefaultCategoriesES
+// GetCategoryByID returns the category with the given ID in a language (following its fallback chain).
+func GetCategoryByID(id string, language string) (domain.Category, bool) {
+	if id == InfiniteCategoryID {
+		return infiniteCategory(), true
+	}
+	if id == CommunityCategoryID {
+		return communityCategory(language)
+	}
+	for _, cat := range infiniteThemeCategories(language) {
+		if cat.ID == id {
+			return cat, true
+		}
+	}
+	for _, c := range CurrentDictionary().Categories(language) {
+		if c.ID == id {
+			return c, true
+		}
+	}
+	return domain.Category{}, false
+}
+
+// ResolveCategory finds a category by ID or by name. A name from another language is translated
+// through its ID ("Animals" requested in Spanish gives "Animales"), so a lobby that switches
+// language keeps its category. Unknown categories fall back like GetCategoryByName.
+func ResolveCategory(ref string, language string) domain.Category {
+	if cat, ok := GetCategoryByID(ref, language); ok {
+		return cat
+	}
+	if ref == InfiniteCategory {
+		return infiniteCategory()
+	}
+
+	d := CurrentDictionary()
+	for _, c := range d.Categories(language) {
+		if c.Name == ref {
+			return c
+		}
+	}
+	for _, themes := range d.themes {
+		for _, theme := range themes {
+			if cat := infiniteThemeCategory(theme); cat.Name == ref {
+				if translated, ok := GetCategoryByID(cat.ID, language); ok {
+					return translated
+				}
+			}
+		}
+	}
+	for _, categories := range d.languages {
+		for _, c := range categories {
+			if c.Name == ref {
+				if cat, ok := GetCategoryByID(c.ID, language); ok {
+					return cat
+				}
+			}
+		}
+	}
+	return GetCategoryByName(ref, language)
+}
+
+func infiniteCategory() domain.Category {
+	return domain.Category{ID: InfiniteCategoryID, Name: InfiniteCategory, Pairs: []domain.WordPair{}}
+}
+
+func infiniteThemeCategory(theme domain.InfiniteTheme) domain.Category {
+	return domain.Category{ID: InfiniteCategoryID + "-" + theme.ID, Name: InfiniteCategory + ": " + theme.Name, Pairs: []domain.WordPair{}}
+}
+
+// infiniteThemeCategories returns the categories of the Infinite themes of a language.
+func infiniteThemeCategories(language string) []domain.Category {
+	themes := CurrentDictionary().Themes(language)
+	categories := make([]domain.Category, len(themes))
+	for i, theme := range themes {
+		categories[i] = infiniteThemeCategory(theme)
+	}
+	return categories
+}
+
+// reservedCategoryName reports whether a name belongs to a category built by the server,
+// ✨ Infinite and its themes or 👥 Community.
+func reservedCategoryName(name string) bool {
+	return IsInfiniteCategory(name) || name == CommunityCategory
+}
+
+// dictionaryCategoryExists reports whether a built-in category, or a category of the dictionary
+// in any language, has this name (ignoring case) or ID.
+func dictionaryCategoryExists(name, id string) bool {
+	if reservedCategoryName(name) || id == InfiniteCategoryID || id == CommunityCategoryID ||
+		strings.HasPrefix(id, InfiniteCategoryID+"-") {
+		return true
+	}
+	d := CurrentDictionary()
+	for _, language := range d.Languages() {
+		categories, _ := d.Lookup(language)
+		for _, cat := range categories {
+			if strings.EqualFold(cat.Name, name) || cat.ID == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsInfiniteCategory reports whether a category name is ✨ Infinite or one of its themes.
+// The prefix is reserved, dictionaries and custom categories can't use it.
+func IsInfiniteCategory(name string) bool {
+	return strings.HasPrefix(name, InfiniteCategory)
+}
+
+// InfiniteThemeOf returns the theme of an Infinite category in a language, or false for the plain
+// ✨ Infinite and any other category.
+func InfiniteThemeOf(cat domain.Category, language string) (domain.InfiniteTheme, bool) {
+	id, ok := strings.CutPrefix(cat.ID, InfiniteCategoryID+"-")
+	if !ok || !IsInfiniteCategory(cat.Name) {
+		return domain.InfiniteTheme{}, false
+	}
+	for _, theme := range CurrentDictionary().Themes(language) {
+		if theme.ID == id {
+			return theme, true
+		}
 	}
+	return domain.InfiniteTheme{}, false
+}
+
+// GetAllCategoryNames lists the categories of a language: ✨ Infinite, the local categories,
+// 👥 Community once players liked some Infinite pairs, then the Infinite themes.
+func GetAllCategoryNames(language string) []string {
+	categories := CurrentDictionary().Categories(language)
+	themes := infiniteThemeCategories(language)
 
-	names := make([]string, 0, len(categories)+1)
-    names = append(names, "✨ Infinite")
+	names := make([]string, 0, len(categories)+len(themes)+2)
+	names = append(names, InfiniteCategory)
 	for _, c := range categories {
 		names = append(names, c.Name)
 	}
+	if c, ok := communityCategory(language); ok {
+		names = append(names, c.Name)
+	}
+	for _, c := range themes {
+		names = append(names, c.Name)
+	}
 	return names
 }
 
+// CategoryRef identifies a category in listings.
+type CategoryRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// GetAllCategoryRefs is GetAllCategoryNames with the category IDs.
+func GetAllCategoryRefs(language string) []CategoryRef {
+	categories := CurrentDictionary().Categories(language)
+	themes := infiniteThemeCategories(language)
+
+	refs := make([]CategoryRef, 0, len(categories)+len(themes)+2)
+	refs = append(refs, CategoryRef{ID: InfiniteCategoryID, Name: InfiniteCategory})
+	for _, c := range categories {
+		refs = append(refs, CategoryRef{ID: c.ID, Name: c.Name})
+	}
+	if c, ok := communityCategory(language); ok {
+		refs = append(refs, CategoryRef{ID: c.ID, Name: c.Name})
+	}
+	for _, c := range themes {
+		refs = append(refs, CategoryRef{ID: c.ID, Name: c.Name})
+	}
+	return refs
+}
+
+// GetRandomCategory returns any local category of the language.
+func GetRandomCategory(language string) domain.Category {
+	categories := CurrentDictionary().Categories(language)
+	return categories[rand.Intn(len(categories))]
+}
+
 func GetRandomWord(categoryName string, language string) (domain.WordPair, error) {
+	pair, _ := DrawWord(categoryName, language, "", nil)
+	return pair, nil
+}
+
+// DrawWord is GetRandomWord for clients keeping their own history, such as the offline mode:
+// pairs whose real word is in exclude are skipped until all of them have been played,
+// then every pair is available again and reshuffled is true.
+// A non-empty difficulty only draws pairs of that difficulty, if the category has any.
+func DrawWord(categoryName string, language string, difficulty domain.Difficulty, exclude []string) (pair domain.WordPair, reshuffled bool) {
 	category := GetCategoryByName(categoryName, language)
 	if len(category.Pairs) == 0 {
-		return domain.WordPair{}, nil
+		return domain.WordPair{}, false
 	}
-	
-    r := rand.New(rand.NewSource(time.Now().UnixNano()))
-    idx := r.Intn(len(category.Pairs))
-    return category.Pairs[idx], nil
+	return NewDeck(exclude...).Draw(filterByDifficulty(category.Pairs, difficulty))
 }
@@ -0,0 +1,230 @@
+package game
+
+import (
+	"fmt"
+	"impostor/internal/domain"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// LintIssue is a problem found in a dictionary by Lint.
+type LintIssue struct {
+	Language string
+	Category string // Empty when the issue concerns the whole language
+	Pair     int    // 1-based, 0 when the issue concerns the whole category
+	Message  string
+}
+
+func (i LintIssue) String() string {
+	where := i.Language
+	if i.Category != "" {
+		where += "/" + i.Category
+	}
+	if i.Pair > 0 {
+		where += fmt.Sprintf(" #%d", i.Pair)
+	}
+	return where + ": " + i.Message
+}
+
+// ReadDictionaryDir parses the dictionary files of dir as they are: without validation and
+// without the built-in languages. Meant for tools such as cmd/dictlint; the server uses LoadDictionaryDir.
+func ReadDictionaryDir(dir string) (*Dictionary, error) {
+	return readDictionaryFS(os.DirFS(dir), ".")
+}
+
+// Lint reports data problems that Validate lets through: duplicated pairs and words, real == trap,
+// empty fields, stray whitespace, and translations that don't line up with DefaultLanguage
+// (categories and pairs are linked across languages by ID) or lack its hints and descriptions.
+func (d *Dictionary) Lint() []LintIssue {
+	languages := d.Languages()
+	sort.Strings(languages)
+
+	var issues []LintIssue
+	for _, lang := range languages {
+		issues = append(issues, lintLanguage(lang, d.languages[lang])...)
+	}
+
+	reference, ok := d.languages[DefaultLanguage]
+	if !ok {
+		return issues
+	}
+	for _, lang := range languages {
+		if lang != DefaultLanguage {
+			issues = append(issues, lintTranslation(lang, d.languages[lang], reference)...)
+		}
+	}
+	return issues
+}
+
+// lintLanguage checks the categories of one language on their own.
+func lintLanguage(lang string, categories []domain.Category) []LintIssue {
+	var issues []LintIssue
+	report := func(category string, pair int, format string, args ...any) {
+		issues = append(issues, LintIssue{Language: lang, Category: category, Pair: pair, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(categories) == 0 {
+		report("", 0, "no categories")
+	}
+
+	// First place each pair and real word was seen, across all categories of the language
+	type position struct {
+		category string
+		pair     int
+	}
+	pairsSeen := make(map[string]position)
+	realsSeen := make(map[string]position)
+
+	for _, cat := range categories {
+		if cat.Name == "" {
+			report("", 0, "category without name")
+		} else if msg := whitespaceProblem(cat.Name); msg != "" {
+			report(cat.Name, 0, "name %s", msg)
+		}
+		if len(cat.Pairs) == 0 {
+			report(cat.Name, 0, "no word pairs")
+		}
+
+		for i, p := range cat.Pairs {
+			n := i + 1
+			for _, word := range []struct{ field, value string }{{"real", p.Real}, {"trap", p.Trap}} {
+				if strings.TrimSpace(word.value) == "" {
+					report(cat.Name, n, "empty %s word", word.field)
+				} else if msg := whitespaceProblem(word.value); msg != "" {
+					report(cat.Name, n, "%s word %q %s", word.field, word.value, msg)
+				}
+			}
+			if p.Difficulty != "" && !validDifficulty(p.Difficulty) {
+				report(cat.Name, n, "invalid difficulty %q", p.Difficulty)
+			}
+			for _, text := range []struct{ field, value string }{{"hint", p.Hint}, {"description", p.Description}} {
+				if text.value != "" {
+					if msg := whitespaceProblem(text.value); msg != "" {
+						report(cat.Name, n, "%s %s", text.field, msg)
+					}
+				}
+			}
+			if p.Hint != "" && strings.TrimSpace(p.Real) != "" && containsWords(p.Hint, p.Real) {
+				report(cat.Name, n, "hint %q gives away the real word", p.Hint)
+			}
+
+			real, trap := lintKey(p.Real), lintKey(p.Trap)
+			if real == "" || trap == "" {
+				continue
+			}
+			if real == trap {
+				report(cat.Name, n, "real and trap are the same word (%q)", p.Real)
+				continue
+			}
+
+			// Pairs are duplicates in either order: Sun/Moon and Moon/Sun play the same
+			key := real + "\x00" + trap
+			if trap < real {
+				key = trap + "\x00" + real
+			}
+			if first, dup := pairsSeen[key]; dup {
+				report(cat.Name, n, "duplicate of %s #%d (%s/%s)", first.category, first.pair, p.Real, p.Trap)
+				continue
+			}
+			pairsSeen[key] = position{cat.Name, n}
+
+			if first, dup := realsSeen[real]; dup {
+				report(cat.Name, n, "real word %q already used in %s #%d", p.Real, first.category, first.pair)
+				continue
+			}
+			realsSeen[real] = position{cat.Name, n}
+		}
+	}
+	return issues
+}
+
+// lintTranslation compares a language with the reference language, linking categories and pairs by ID.
+func lintTranslation(lang string, categories, reference []domain.Category) []LintIssue {
+	var issues []LintIssue
+	report := func(category string, pair int, format string, args ...any) {
+		issues = append(issues, LintIssue{Language: lang, Category: category, Pair: pair, Message: fmt.Sprintf(format, args...)})
+	}
+
+	byID := make(map[string]domain.Category, len(categories))
+	for _, cat := range categories {
+		byID[cat.ID] = cat
+	}
+	refIDs := make(map[string]bool, len(reference))
+
+	for _, ref := range reference {
+		refIDs[ref.ID] = true
+		cat, ok := byID[ref.ID]
+		if !ok {
+			report("", 0, "missing translation of category %s/%s (id %q)", DefaultLanguage, ref.Name, ref.ID)
+			continue
+		}
+		if len(cat.Pairs) != len(ref.Pairs) {
+			report(cat.Name, 0, "%d pairs, %s/%s has %d", len(cat.Pairs), DefaultLanguage, ref.Name, len(ref.Pairs))
+		}
+
+		pairIndex := make(map[string]int, len(cat.Pairs))
+		for i, p := range cat.Pairs {
+			pairIndex[p.ID] = i
+		}
+		refPairIDs := make(map[string]bool, len(ref.Pairs))
+		for i, p := range ref.Pairs {
+			refPairIDs[p.ID] = true
+			j, ok := pairIndex[p.ID]
+			if !ok {
+				report(cat.Name, 0, "missing translation of %s/%s #%d (%s/%s, id %q)", DefaultLanguage, ref.Name, i+1, p.Real, p.Trap, p.ID)
+				continue
+			}
+			// Players of a language without them would get the reference ones (see TranslatePair)
+			translated := cat.Pairs[j]
+			for _, text := range []struct{ field, ref, value string }{
+				{"hint", p.Hint, translated.Hint},
+				{"description", p.Description, translated.Description},
+			} {
+				if text.ref != "" && text.value == "" {
+					report(cat.Name, j+1, "no %s, unlike %s/%s #%d (%s/%s)", text.field, DefaultLanguage, ref.Name, i+1, p.Real, p.Trap)
+				}
+			}
+		}
+		for i, p := range cat.Pairs {
+			if !refPairIDs[p.ID] {
+				report(cat.Name, i+1, "pair id %q has no %s counterpart", p.ID, DefaultLanguage)
+			}
+		}
+	}
+
+	for _, cat := range categories {
+		if !refIDs[cat.ID] {
+			report(cat.Name, 0, "category id %q has no %s counterpart", cat.ID, DefaultLanguage)
+		}
+	}
+	return issues
+}
+
+// whitespaceProblem describes whitespace that is likely a typo, or returns "".
+func whitespaceProblem(s string) string {
+	switch {
+	case strings.TrimSpace(s) != s:
+		return "has leading or trailing whitespace"
+	case strings.Contains(s, "  "):
+		return "has repeated spaces"
+	case strings.IndexFunc(s, func(r rune) bool { return unicode.IsSpace(r) && r != ' ' }) >= 0:
+		return "has tabs, newlines or non-breaking spaces"
+	}
+	return ""
+}
+
+// containsWords reports whether the words of phrase appear in text, in order and as whole words.
+func containsWords(text, phrase string) bool {
+	words := func(s string) string {
+		return " " + strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}), " ") + " "
+	}
+	return strings.Contains(words(text), words(phrase))
+}
+
+func lintKey(word string) string {
+	return strings.ToLower(strings.TrimSpace(word))
+}
@@ -0,0 +1,48 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "en.json", `{"categories": [
+		{"id": "general", "name": "General", "pairs": [{"id": "sun", "real": "Sun", "trap": "Moon", "description": "Our star."}, {"id": "library", "real": "Library", "trap": "Bookstore"}, {"id": "pen", "real": "Pen", "trap": "Pencil", "hint": "A pen, for writing"}]},
+		{"id": "places", "name": "Places", "pairs": [{"real": "Moon", "trap": "Sun"}, {"real": "library", "trap": "Museum"}, {"real": "Zoo", "trap": "zoo"}]}
+	]}`)
+	writeFile(t, dir, "es.json", `{"categories": [
+		{"id": "general", "name": "General", "pairs": [{"id": "sun", "real": "Sol ", "trap": "Luna"}, {"id": "library", "real": "Biblioteca", "trap": ""}, {"id": "cat", "real": "Gato", "trap": "Tigre"}]}
+	]}`)
+
+	d, err := ReadDictionaryDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDictionaryDir() error = %v", err)
+	}
+
+	var report []string
+	for _, issue := range d.Lint() {
+		report = append(report, issue.String())
+	}
+	got := strings.Join(report, "\n")
+
+	for _, want := range []string{
+		`en/Places #1: duplicate of General #1 (Moon/Sun)`,
+		`en/Places #2: real word "library" already used in General #2`,
+		`en/Places #3: real and trap are the same word ("Zoo")`,
+		`en/General #3: hint "A pen, for writing" gives away the real word`,
+		`es/General #1: real word "Sol " has leading or trailing whitespace`,
+		`es/General #2: empty trap word`,
+		`es/General #1: no description, unlike en/General #1 (Sun/Moon)`,
+		`es/General: missing translation of en/General #3 (Pen/Pencil, id "pen")`,
+		`es/General #3: pair id "cat" has no en counterpart`,
+		`es: missing translation of category en/Places (id "places")`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing issue %q in report:\n%s", want, got)
+		}
+	}
+	if len(report) != 10 {
+		t.Errorf("expected 10 issues, got %d:\n%s", len(report), got)
+	}
+}
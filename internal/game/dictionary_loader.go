@@ -0,0 +1,317 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"impostor/internal/domain"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// dictionaryFile is the on-disk format of one language, in JSON or YAML:
+//
+//	language: es
+//	categories:
+//	  - name: Animales
+//	    pairs:
+//	      - {real: Perro, trap: Lobo}
+//	infinite_themes:
+//	  - {name: Animales, topics: [animales], seeds: [perro, gato]}
+//
+// A file without infinite_themes keeps the built-in themes of its language.
+type dictionaryFile struct {
+	Language   string                 `json:"language" yaml:"language"`
+	Categories []domain.Category      `json:"categories" yaml:"categories"`
+	Themes     []domain.InfiniteTheme `json:"infinite_themes,omitempty" yaml:"infinite_themes,omitempty"`
+}
+
+// LoadDictionaryDir loads every *.json, *.yaml and *.yml file in dir, one language per file.
+// Languages missing from dir are taken from the built-in dictionary.
+func LoadDictionaryDir(dir string) (*Dictionary, error) {
+	d, err := readDictionaryFS(os.DirFS(dir), ".")
+	if err != nil {
+		return nil, err
+	}
+
+	builtin, err := loadBuiltinDictionary()
+	if err != nil {
+		return nil, err
+	}
+	for lang, categories := range builtin.languages {
+		if _, ok := d.languages[lang]; !ok {
+			d.languages[lang] = categories
+		}
+	}
+	for lang, themes := range builtin.themes {
+		if _, ok := d.themes[lang]; !ok {
+			d.themes[lang] = themes
+			d.builtin[lang] = true
+		}
+	}
+
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func loadBuiltinDictionary() (*Dictionary, error) {
+	d, err := readDictionaryFS(builtinDictionaries, "data/dictionaries")
+	if err != nil {
+		return nil, err
+	}
+	for lang := range d.themes {
+		d.builtin[lang] = true
+	}
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// readDictionaryFS parses the dictionary files of a directory, without validating them.
+func readDictionaryFS(fsys fs.FS, dir string) (*Dictionary, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Dictionary{
+		languages: make(map[string][]domain.Category),
+		themes:    make(map[string][]domain.InfiniteTheme),
+		builtin:   make(map[string]bool),
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !isDictionaryFile(entry.Name()) {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		file, err := parseDictionaryFile(entry.Name(), data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		if _, dup := d.languages[file.Language]; dup {
+			return nil, fmt.Errorf("%s: language %q defined twice", entry.Name(), file.Language)
+		}
+		d.languages[file.Language] = file.Categories
+		if file.Themes != nil {
+			d.themes[file.Language] = file.Themes
+		}
+	}
+	return d, nil
+}
+
+func isDictionaryFile(name string) bool {
+	switch path.Ext(name) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// parseDictionaryFile decodes a file according to its extension.
+// The language defaults to the file name without extension (es.yaml -> "es").
+func parseDictionaryFile(name string, data []byte) (dictionaryFile, error) {
+	var file dictionaryFile
+	var err error
+	if path.Ext(name) == ".json" {
+		err = json.Unmarshal(data, &file)
+	} else {
+		err = yaml.Unmarshal(data, &file)
+	}
+	if err != nil {
+		return file, err
+	}
+
+	if file.Language == "" {
+		file.Language = strings.TrimSuffix(name, path.Ext(name))
+	}
+	assignIDs(file.Categories)
+	assignThemeIDs(file.Themes)
+	return file, nil
+}
+
+// Validate checks the dictionary is usable by the game.
+// A dictionary failing validation is never swapped in.
+func (d *Dictionary) Validate() error {
+	if _, ok := d.languages[DefaultLanguage]; !ok {
+		return fmt.Errorf("missing default language %q", DefaultLanguage)
+	}
+
+	for lang, categories := range d.languages {
+		if len(categories) == 0 {
+			return fmt.Errorf("%s: no categories", lang)
+		}
+		seen := make(map[string]bool, len(categories))
+		seenIDs := make(map[string]bool, len(categories))
+		for _, c := range categories {
+			if strings.TrimSpace(c.Name) == "" {
+				return fmt.Errorf("%s: category without name", lang)
+			}
+			if reservedCategoryName(c.Name) || c.ID == CommunityCategoryID {
+				return fmt.Errorf("%s: category %q is reserved", lang, c.Name)
+			}
+			if seen[c.Name] {
+				return fmt.Errorf("%s: duplicate category %q", lang, c.Name)
+			}
+			seen[c.Name] = true
+			if c.ID == "" || seenIDs[c.ID] {
+				return fmt.Errorf("%s/%s: missing or duplicate category id %q", lang, c.Name, c.ID)
+			}
+			seenIDs[c.ID] = true
+
+			if len(c.Pairs) == 0 {
+				return fmt.Errorf("%s/%s: no word pairs", lang, c.Name)
+			}
+			pairIDs := make(map[string]bool, len(c.Pairs))
+			for i, p := range c.Pairs {
+				if p.ID == "" || pairIDs[p.ID] {
+					return fmt.Errorf("%s/%s: pair %d has a missing or duplicate id %q", lang, c.Name, i+1, p.ID)
+				}
+				pairIDs[p.ID] = true
+				if strings.TrimSpace(p.Real) == "" || strings.TrimSpace(p.Trap) == "" {
+					return fmt.Errorf("%s/%s: pair %d has an empty word", lang, c.Name, i+1)
+				}
+				if p.Difficulty != "" && !validDifficulty(p.Difficulty) {
+					return fmt.Errorf("%s/%s: pair %d has an invalid difficulty %q", lang, c.Name, i+1, p.Difficulty)
+				}
+			}
+		}
+	}
+
+	for lang, themes := range d.themes {
+		if err := validateThemes(themes); err != nil {
+			return fmt.Errorf("%s: %w", lang, err)
+		}
+	}
+	return nil
+}
+
+// maxThemeTopics is the number of topic words Datamuse takes into account.
+const maxThemeTopics = 5
+
+func validateThemes(themes []domain.InfiniteTheme) error {
+	seen := make(map[string]bool, len(themes))
+	seenIDs := make(map[string]bool, len(themes))
+	for _, t := range themes {
+		if strings.TrimSpace(t.Name) == "" {
+			return fmt.Errorf("infinite theme without name")
+		}
+		if seen[t.Name] {
+			return fmt.Errorf("duplicate infinite theme %q", t.Name)
+		}
+		seen[t.Name] = true
+		if t.ID == "" || seenIDs[t.ID] {
+			return fmt.Errorf("infinite theme %s: missing or duplicate id %q", t.Name, t.ID)
+		}
+		seenIDs[t.ID] = true
+		if len(t.Topics) > maxThemeTopics {
+			return fmt.Errorf("infinite theme %s: more than %d topics", t.Name, maxThemeTopics)
+		}
+		if len(t.Seeds) == 0 {
+			return fmt.Errorf("infinite theme %s: no seed words", t.Name)
+		}
+		for _, seed := range t.Seeds {
+			if strings.TrimSpace(seed) == "" {
+				return fmt.Errorf("infinite theme %s: empty seed word", t.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// DictionaryWatcher reloads the dictionary whenever the files in a directory change.
+type DictionaryWatcher struct {
+	dir         string
+	interval    time.Duration
+	fingerprint string
+}
+
+// NewDictionaryWatcher creates a watcher for dir, polling every interval.
+func NewDictionaryWatcher(dir string, interval time.Duration) *DictionaryWatcher {
+	return &DictionaryWatcher{dir: dir, interval: interval}
+}
+
+// Load performs the initial load. On failure the built-in dictionary stays in use.
+func (w *DictionaryWatcher) Load() error {
+	_, err := w.reload()
+	return err
+}
+
+// Watch polls the directory until ctx is cancelled.
+func (w *DictionaryWatcher) Watch(ctx context.Context) {
+	if w.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if reloaded, err := w.reload(); err != nil {
+				log.Printf("Dictionary reload failed, keeping the previous version: %v", err)
+			} else if reloaded {
+				log.Printf("Dictionary reloaded from %s", w.dir)
+			}
+		}
+	}
+}
+
+// reload loads the directory if it changed since the last successful or failed attempt.
+// Returns true if a new dictionary was swapped in.
+func (w *DictionaryWatcher) reload() (bool, error) {
+	fingerprint, err := dirFingerprint(w.dir)
+	if err != nil {
+		return false, err
+	}
+	if fingerprint == w.fingerprint {
+		return false, nil
+	}
+	// Remember the attempt even if it fails, so a broken file is reported once, not on every tick.
+	w.fingerprint = fingerprint
+
+	d, err := LoadDictionaryDir(w.dir)
+	if err != nil {
+		return false, err
+	}
+	SetDictionary(d)
+	return true, nil
+}
+
+// dirFingerprint summarizes the name, size and modification time of the dictionary files in dir.
+func dirFingerprint(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+
+	parts := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !isDictionaryFile(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, fmt.Sprintf("%s:%d:%d", entry.Name(), info.Size(), info.ModTime().UnixNano()))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "|"), nil
+}
@@ -0,0 +1,115 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadDictionaryDir(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "en.json", `{"categories": [{"name": "Office", "pairs": [{"real": "Stapler", "trap": "Clip"}]}]}`)
+	writeFile(t, dir, "es.yaml", `
+categories:
+  - name: Oficina
+    pairs:
+      - {real: Grapadora, trap: Clip}
+`)
+
+	d, err := LoadDictionaryDir(dir)
+	if err != nil {
+		t.Fatalf("LoadDictionaryDir() error = %v", err)
+	}
+	if got := d.Categories("en")[0].Name; got != "Office" {
+		t.Errorf("en category = %q, want Office", got)
+	}
+	if got := d.Categories("es")[0].Pairs[0].Real; got != "Grapadora" {
+		t.Errorf("es pair = %q, want Grapadora", got)
+	}
+}
+
+func TestLoadDictionaryDirFallsBackToBuiltin(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "es.yml", `
+categories:
+  - name: Oficina
+    pairs:
+      - {real: Grapadora, trap: Clip}
+`)
+
+	d, err := LoadDictionaryDir(dir)
+	if err != nil {
+		t.Fatalf("LoadDictionaryDir() error = %v", err)
+	}
+	if got := d.Categories("en")[0].Name; got != "General" {
+		t.Errorf("missing language should come from the built-in set, got %q", got)
+	}
+}
+
+func TestLoadDictionaryDirValidation(t *testing.T) {
+	tests := map[string]string{
+		"empty trap":         `{"categories": [{"name": "X", "pairs": [{"real": "A", "trap": " "}]}]}`,
+		"no pairs":           `{"categories": [{"name": "X", "pairs": []}]}`,
+		"no categories":      `{"categories": []}`,
+		"reserved name":      `{"categories": [{"name": "✨ Infinite", "pairs": [{"real": "A", "trap": "B"}]}]}`,
+		"reserved theme":     `{"categories": [{"name": "✨ Infinite: Cars", "pairs": [{"real": "A", "trap": "B"}]}]}`,
+		"reserved community": `{"categories": [{"name": "👥 Community", "pairs": [{"real": "A", "trap": "B"}]}]}`,
+		"reserved id":        `{"categories": [{"id": "community", "name": "Friends", "pairs": [{"real": "A", "trap": "B"}]}]}`,
+		"theme no seeds":     `{"categories": [{"name": "X", "pairs": [{"real": "A", "trap": "B"}]}], "infinite_themes": [{"name": "Cars", "seeds": []}]}`,
+		"theme topics":       `{"categories": [{"name": "X", "pairs": [{"real": "A", "trap": "B"}]}], "infinite_themes": [{"name": "Cars", "topics": ["a", "b", "c", "d", "e", "f"], "seeds": ["car"]}]}`,
+		"malformed json":     `{"categories": [`,
+		"bad difficulty":     `{"categories": [{"name": "X", "pairs": [{"real": "A", "trap": "B", "difficulty": "brutal"}]}]}`,
+		"duplicate id":       `{"categories": [{"id": "x", "name": "X", "pairs": [{"real": "A", "trap": "B"}]}, {"id": "x", "name": "Y", "pairs": [{"real": "A", "trap": "B"}]}]}`,
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFile(t, dir, "en.json", content)
+			if _, err := LoadDictionaryDir(dir); err == nil {
+				t.Error("expected a validation error")
+			}
+		})
+	}
+}
+
+func TestDictionaryWatcherKeepsLastGood(t *testing.T) {
+	original := CurrentDictionary()
+	defer SetDictionary(original)
+
+	dir := t.TempDir()
+	writeFile(t, dir, "en.json", `{"categories": [{"name": "Office", "pairs": [{"real": "Stapler", "trap": "Clip"}]}]}`)
+
+	w := NewDictionaryWatcher(dir, time.Hour)
+	if err := w.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := GetAllCategoryNames("en")[1]; got != "Office" {
+		t.Fatalf("category = %q, want Office", got)
+	}
+
+	// A broken edit is rejected and the previous version stays in use.
+	writeFile(t, dir, "en.json", `{"categories": [{"name": "Office", "pairs": []}]}`)
+	if _, err := w.reload(); err == nil {
+		t.Error("reload() should fail on an invalid dictionary")
+	}
+	if got := GetCategoryByName("Office", "en").Pairs[0].Real; got != "Stapler" {
+		t.Errorf("pair = %q, want the last good version", got)
+	}
+
+	// Fixing the file brings the new content in.
+	writeFile(t, dir, "en.json", `{"categories": [{"name": "Office", "pairs": [{"real": "Desk", "trap": "Table"}]}]}`)
+	if reloaded, err := w.reload(); err != nil || !reloaded {
+		t.Fatalf("reload() = %v, %v", reloaded, err)
+	}
+	if got := GetCategoryByName("Office", "en").Pairs[0].Real; got != "Desk" {
+		t.Errorf("pair = %q, want Desk", got)
+	}
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
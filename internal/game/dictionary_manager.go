@@ -0,0 +1,209 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"impostor/internal/domain"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrCategoryExists   = errors.New("category already exists")
+	ErrPairNotFound     = errors.New("word pair not found")
+	ErrInvalidLanguage  = errors.New("invalid language code")
+)
+
+// languageCode accepts codes like "en", "pt-BR" or "ast".
+var languageCode = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+
+// DictionaryManager applies edits to the live dictionary.
+// Every edit is validated, persisted through the store and then swapped in, so it is visible
+// to GetAllCategoryNames and new games immediately.
+type DictionaryManager struct {
+	mu    sync.Mutex // Serializes edits; readers use CurrentDictionary without locking
+	store DictionaryStore
+}
+
+// NewDictionaryManager creates a manager persisting to store.
+func NewDictionaryManager(store DictionaryStore) *DictionaryManager {
+	return &DictionaryManager{store: store}
+}
+
+// CreateCategory adds a new category to a language.
+func (m *DictionaryManager) CreateCategory(language string, cat domain.Category) error {
+	return m.edit(language, func(categories []domain.Category) ([]domain.Category, error) {
+		if findCategory(categories, cat.Name) >= 0 {
+			return nil, ErrCategoryExists
+		}
+		return append(categories, cat), nil
+	})
+}
+
+// UpdateCategory replaces a category, possibly renaming it. The category keeps its ID unless a new one is given.
+func (m *DictionaryManager) UpdateCategory(language, name string, cat domain.Category) error {
+	return m.edit(language, func(categories []domain.Category) ([]domain.Category, error) {
+		i := findCategory(categories, name)
+		if i < 0 {
+			return nil, ErrCategoryNotFound
+		}
+		if cat.Name != name && findCategory(categories, cat.Name) >= 0 {
+			return nil, ErrCategoryExists
+		}
+		if cat.ID == "" {
+			cat.ID = categories[i].ID
+		}
+		categories[i] = cat
+		return categories, nil
+	})
+}
+
+// DeleteCategory removes a category from a language.
+func (m *DictionaryManager) DeleteCategory(language, name string) error {
+	return m.edit(language, func(categories []domain.Category) ([]domain.Category, error) {
+		i := findCategory(categories, name)
+		if i < 0 {
+			return nil, ErrCategoryNotFound
+		}
+		return append(categories[:i], categories[i+1:]...), nil
+	})
+}
+
+// AddPairs appends word pairs to a category.
+func (m *DictionaryManager) AddPairs(language, name string, pairs []domain.WordPair) error {
+	return m.editCategory(language, name, func(cat *domain.Category) error {
+		cat.Pairs = append(cat.Pairs, pairs...)
+		return nil
+	})
+}
+
+// UpdatePair replaces the pair at index (0-based) of a category.
+func (m *DictionaryManager) UpdatePair(language, name string, index int, pair domain.WordPair) error {
+	return m.editCategory(language, name, func(cat *domain.Category) error {
+		if index < 0 || index >= len(cat.Pairs) {
+			return ErrPairNotFound
+		}
+		if pair.ID == "" {
+			pair.ID = cat.Pairs[index].ID
+		}
+		cat.Pairs[index] = pair
+		return nil
+	})
+}
+
+// DeletePair removes the pair at index (0-based) of a category.
+func (m *DictionaryManager) DeletePair(language, name string, index int) error {
+	return m.editCategory(language, name, func(cat *domain.Category) error {
+		if index < 0 || index >= len(cat.Pairs) {
+			return ErrPairNotFound
+		}
+		cat.Pairs = append(cat.Pairs[:index], cat.Pairs[index+1:]...)
+		return nil
+	})
+}
+
+// Import adds categories in bulk. With replace, the language is replaced entirely;
+// otherwise existing categories with the same name are overwritten and the rest kept.
+func (m *DictionaryManager) Import(language string, imported []domain.Category, replace bool) error {
+	return m.edit(language, func(categories []domain.Category) ([]domain.Category, error) {
+		if replace {
+			return imported, nil
+		}
+		for _, cat := range imported {
+			if i := findCategory(categories, cat.Name); i >= 0 {
+				categories[i] = cat
+			} else {
+				categories = append(categories, cat)
+			}
+		}
+		return categories, nil
+	})
+}
+
+// editCategory applies fn to a copy of one category.
+func (m *DictionaryManager) editCategory(language, name string, fn func(cat *domain.Category) error) error {
+	return m.edit(language, func(categories []domain.Category) ([]domain.Category, error) {
+		i := findCategory(categories, name)
+		if i < 0 {
+			return nil, ErrCategoryNotFound
+		}
+		if err := fn(&categories[i]); err != nil {
+			return nil, err
+		}
+		return categories, nil
+	})
+}
+
+// edit runs fn on a deep copy of the categories of a language, then validates, persists and swaps in the result.
+func (m *DictionaryManager) edit(language string, fn func(categories []domain.Category) ([]domain.Category, error)) error {
+	if !languageCode.MatchString(language) {
+		return ErrInvalidLanguage
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current := CurrentDictionary()
+	categories, err := fn(copyCategories(current.languages[language]))
+	if err != nil {
+		return err
+	}
+	assignIDs(categories)
+	for _, cat := range categories {
+		if err := checkPairs(cat); err != nil {
+			return err
+		}
+	}
+
+	next := current.withLanguage(language, categories)
+	if err := next.Validate(); err != nil {
+		return err
+	}
+	if err := m.store.Save(language, categories, current.fileThemes(language)); err != nil {
+		return fmt.Errorf("saving dictionary: %w", err)
+	}
+	SetDictionary(next)
+	return nil
+}
+
+// checkPairs applies the rules for newly edited pairs, stricter than Validate
+// so that existing files with minor issues still load.
+func checkPairs(cat domain.Category) error {
+	for i, p := range cat.Pairs {
+		if strings.EqualFold(strings.TrimSpace(p.Real), strings.TrimSpace(p.Trap)) {
+			return fmt.Errorf("%s: pair %d uses the same word twice (%q)", cat.Name, i+1, p.Real)
+		}
+	}
+	return nil
+}
+
+// withLanguage returns a copy of the dictionary with the categories of one language replaced.
+// The Infinite themes are shared, edits don't change them.
+func (d *Dictionary) withLanguage(language string, categories []domain.Category) *Dictionary {
+	next := &Dictionary{languages: make(map[string][]domain.Category, len(d.languages)+1), themes: d.themes, builtin: d.builtin}
+	for lang, cats := range d.languages {
+		next.languages[lang] = cats
+	}
+	next.languages[language] = categories
+	return next
+}
+
+func findCategory(categories []domain.Category, name string) int {
+	for i, c := range categories {
+		if c.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func copyCategories(categories []domain.Category) []domain.Category {
+	out := make([]domain.Category, len(categories))
+	for i, c := range categories {
+		out[i] = c
+		out[i].Pairs = append([]domain.WordPair(nil), c.Pairs...)
+	}
+	return out
+}
@@ -0,0 +1,90 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"impostor/internal/domain"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// DictionaryStore persists the categories edited through the DictionaryManager.
+type DictionaryStore interface {
+	// Save replaces the stored categories of a language. The Infinite themes defined by the file of
+	// the language are saved along, so that the file keeps them; nil themes keep the built-in ones.
+	Save(language string, categories []domain.Category, themes []domain.InfiniteTheme) error
+}
+
+// FileDictionaryStore writes one <language>.json file per language into a directory,
+// in the same format read by LoadDictionaryDir.
+type FileDictionaryStore struct {
+	dir string
+}
+
+// NewFileDictionaryStore stores dictionaries in dir, usually the watched DICTIONARY_DIR.
+func NewFileDictionaryStore(dir string) *FileDictionaryStore {
+	return &FileDictionaryStore{dir: dir}
+}
+
+// MarshalDictionary encodes the categories and Infinite themes of a language as a JSON dictionary file,
+// the format read by LoadDictionaryDir.
+func MarshalDictionary(language string, categories []domain.Category, themes []domain.InfiniteTheme) ([]byte, error) {
+	data, err := json.MarshalIndent(dictionaryFile{Language: language, Categories: categories, Themes: themes}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func (s *FileDictionaryStore) Save(language string, categories []domain.Category, themes []domain.InfiniteTheme) error {
+	data, err := MarshalDictionary(language, categories, themes)
+	if err != nil {
+		return err
+	}
+
+	// Write then rename, so the watcher never reads a half-written file.
+	tmp, err := os.CreateTemp(s.dir, "."+language+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(s.dir, language+".json")); err != nil {
+		return err
+	}
+
+	// Another format may already hold this language; remove it, only now that the new file is in place,
+	// so there's a single source. A reload in between fails on the duplicate and is retried on the next change.
+	for _, ext := range []string{".yaml", ".yml"} {
+		if err := os.Remove(filepath.Join(s.dir, language+ext)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// MemoryDictionaryStore keeps the edits in memory only. Used when no dictionary directory is configured,
+// in which case edits are lost on restart.
+type MemoryDictionaryStore struct {
+	mu        sync.Mutex
+	languages map[string][]domain.Category
+}
+
+func NewMemoryDictionaryStore() *MemoryDictionaryStore {
+	return &MemoryDictionaryStore{languages: make(map[string][]domain.Category)}
+}
+
+func (s *MemoryDictionaryStore) Save(language string, categories []domain.Category, _ []domain.InfiniteTheme) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.languages[language] = categories
+	return nil
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"impostor/internal/domain"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileDictionaryStoreSave(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "en.yaml", "language: en\ncategories: []\n")
+	store := NewFileDictionaryStore(dir)
+	categories := []domain.Category{{ID: "office", Name: "Office", Pairs: []domain.WordPair{{Real: "Jira", Trap: "Trello"}}}}
+
+	// A failed save leaves the existing file alone
+	if err := os.MkdirAll(filepath.Join(dir, "en.json", "busy"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save("en", categories, nil); err == nil {
+		t.Fatal("Save() over a directory should fail")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "en.yaml")); err != nil {
+		t.Errorf("en.yaml was lost by a failed save: %v", err)
+	}
+
+	// A successful one replaces it
+	os.RemoveAll(filepath.Join(dir, "en.json"))
+	if err := store.Save("en", categories, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "en.yaml")); !os.IsNotExist(err) {
+		t.Errorf("en.yaml should be replaced by en.json: %v", err)
+	}
+	d, err := LoadDictionaryDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := d.Lookup("en"); len(got) != 1 || got[0].Name != "Office" {
+		t.Errorf("saved categories = %+v", got)
+	}
+}
+
+func TestDictionaryManagerSavesFileThemesOnly(t *testing.T) {
+	original := CurrentDictionary()
+	defer SetDictionary(original)
+
+	dir := t.TempDir()
+	writeFile(t, dir, "es.yaml", "language: es\ncategories:\n  - {name: Animales, pairs: [{real: Perro, trap: Lobo}]}\ninfinite_themes:\n  - {id: birds, name: Aves, seeds: [águila]}\n")
+	d, err := LoadDictionaryDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetDictionary(d)
+
+	m := NewDictionaryManager(NewFileDictionaryStore(dir))
+	office := domain.Category{Name: "Office", Pairs: []domain.WordPair{{Real: "Jira", Trap: "Trello"}}}
+	for _, language := range []string{"en", "es"} {
+		if err := m.CreateCategory(language, office); err != nil {
+			t.Fatalf("CreateCategory(%s) error = %v", language, err)
+		}
+	}
+
+	saved, err := LoadDictionaryDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// English keeps following the built-in themes instead of a copy of them
+	if !saved.builtin["en"] || len(saved.Themes("en")) != len(original.Themes("en")) {
+		t.Errorf("English themes were written to en.json: %+v", saved.fileThemes("en"))
+	}
+	if themes := saved.fileThemes("es"); len(themes) != 1 || themes[0].Name != "Aves" {
+		t.Errorf("Spanish themes = %+v, want the ones of the file", themes)
+	}
+}
@@ -15,6 +15,7 @@ func TestGetCategoryByName(t *testing.T) {
 		{"Spanish existing", "Animales", "es", "Animales"},
 		{"Default fallback", "NonExistent", "en", "General"},
 		{"Infinite category", "✨ Infinite", "en", "✨ Infinite"},
+		{"Infinite theme", "✨ Infinite: Animals", "en", "✨ Infinite: Animals"},
 	}
 
 	for _, tt := range tests {
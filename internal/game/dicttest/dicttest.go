@@ -0,0 +1,25 @@
+// Package dicttest provides test helpers that run the dictionary lint (see cmd/dictlint) from go test.
+package dicttest
+
+import (
+	"impostor/internal/game"
+	"testing"
+)
+
+// RequireClean reports every lint issue of d as a test error.
+func RequireClean(t testing.TB, d *game.Dictionary) {
+	t.Helper()
+	for _, issue := range d.Lint() {
+		t.Error(issue)
+	}
+}
+
+// RequireCleanDir lints the dictionary files of dir, for instance a DICTIONARY_DIR kept under version control.
+func RequireCleanDir(t testing.TB, dir string) {
+	t.Helper()
+	d, err := game.ReadDictionaryDir(dir)
+	if err != nil {
+		t.Fatalf("reading dictionaries: %v", err)
+	}
+	RequireClean(t, d)
+}
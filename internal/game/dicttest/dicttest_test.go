@@ -0,0 +1,10 @@
+package dicttest
+
+import (
+	"impostor/internal/game"
+	"testing"
+)
+
+func TestBuiltinDictionaryIsClean(t *testing.T) {
+	RequireClean(t, game.CurrentDictionary())
+}
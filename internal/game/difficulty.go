@@ -0,0 +1,52 @@
+package game
+
+import (
+	"errors"
+	"impostor/internal/domain"
+	"strings"
+)
+
+var ErrInvalidDifficulty = errors.New("difficulty must be easy, medium or hard")
+
+// ParseDifficulty reads a difficulty filter as given by clients. An empty string means any difficulty.
+func ParseDifficulty(s string) (domain.Difficulty, error) {
+	d := domain.Difficulty(strings.ToLower(strings.TrimSpace(s)))
+	if d != "" && !validDifficulty(d) {
+		return "", ErrInvalidDifficulty
+	}
+	return d, nil
+}
+
+// DifficultyOf returns the difficulty of a pair, counting unrated pairs as medium.
+func DifficultyOf(p domain.WordPair) domain.Difficulty {
+	if p.Difficulty == "" {
+		return domain.DifficultyMedium
+	}
+	return p.Difficulty
+}
+
+// filterByDifficulty keeps the pairs of the given difficulty. When the filter is empty, or no pair
+// matches it, every pair is returned so a category never runs dry because of a filter.
+func filterByDifficulty(pairs []domain.WordPair, difficulty domain.Difficulty) []domain.WordPair {
+	if difficulty == "" {
+		return pairs
+	}
+	filtered := make([]domain.WordPair, 0, len(pairs))
+	for _, p := range pairs {
+		if DifficultyOf(p) == difficulty {
+			filtered = append(filtered, p)
+		}
+	}
+	if len(filtered) == 0 {
+		return pairs
+	}
+	return filtered
+}
+
+func validDifficulty(d domain.Difficulty) bool {
+	switch d {
+	case domain.DifficultyEasy, domain.DifficultyMedium, domain.DifficultyHard:
+		return true
+	}
+	return false
+}
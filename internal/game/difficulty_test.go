@@ -0,0 +1,107 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"impostor/internal/domain"
+	"testing"
+)
+
+func TestParseDifficulty(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    domain.Difficulty
+		wantErr bool
+	}{
+		{"", "", false},
+		{"hard", domain.DifficultyHard, false},
+		{" Easy ", domain.DifficultyEasy, false},
+		{"brutal", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseDifficulty(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseDifficulty(%q) = %q, %v; want %q, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestFilterByDifficulty(t *testing.T) {
+	pairs := []domain.WordPair{
+		{Real: "Sun", Trap: "Moon", Difficulty: domain.DifficultyEasy},
+		{Real: "Frog", Trap: "Toad", Difficulty: domain.DifficultyHard},
+		{Real: "Dog", Trap: "Wolf"},
+	}
+
+	if got := filterByDifficulty(pairs, domain.DifficultyMedium); len(got) != 1 || got[0].Real != "Dog" {
+		t.Errorf("unrated pairs should count as medium, got %v", got)
+	}
+	if got := filterByDifficulty(pairs[:1], domain.DifficultyHard); len(got) != 1 {
+		t.Errorf("a filter matching nothing should keep every pair, got %v", got)
+	}
+
+	for range 10 {
+		if pair, _ := DrawWord("Animals", "en", domain.DifficultyHard, nil); pair.Difficulty != domain.DifficultyHard {
+			t.Fatalf("DrawWord(hard) returned %+v", pair)
+		}
+	}
+}
+
+func TestFinishedReportsDifficulty(t *testing.T) {
+	h := NewHub()
+	l, _ := h.CreateLobby("finished", nil)
+	l.AddPlayerSafe(&domain.Player{ID: "p1", Name: "Ana", Role: domain.RoleImpostor})
+	client := &fakeClient{}
+	l.RegisterClient("p1", client)
+	l.CurrentPair = domain.WordPair{Real: "Frog", Trap: "Toad", Difficulty: domain.DifficultyHard}
+
+	l.finishGame("p1")
+
+	msg := client.sent[len(client.sent)-1].(map[string]interface{})
+	if msg["difficulty"] != domain.DifficultyHard {
+		t.Errorf("FINISHED difficulty = %v, want hard", msg["difficulty"])
+	}
+}
+
+func TestStartGameWithKeepsRunningSettings(t *testing.T) {
+	h := NewHub()
+	l, _ := h.CreateLobby("settings", nil)
+	for _, id := range []string{"p1", "p2", "p3"} {
+		l.AddPlayerSafe(&domain.Player{ID: id, Name: id})
+	}
+	start := GameSettings{Mode: domain.ModeHard, Category: "animals", Language: "en", Difficulty: domain.DifficultyHard, Adaptive: true}
+	if err := l.StartGameWith(context.Background(), start); err != nil {
+		t.Fatal(err)
+	}
+	if l.Config.Language != "en" || l.Config.Difficulty != domain.DifficultyHard || l.Config.Category != "animals" || !l.Config.Adaptive {
+		t.Errorf("Config = %+v, want the settings of START_GAME", l.Config)
+	}
+
+	// A START_GAME during the game is rejected and changes nothing
+	restart := GameSettings{Mode: domain.ModeEasy, Category: "food", Language: "es", Difficulty: domain.DifficultyEasy}
+	if err := l.StartGameWith(context.Background(), restart); !errors.Is(err, ErrGameInProgress) {
+		t.Errorf("StartGameWith() during a game: err = %v, want ErrGameInProgress", err)
+	}
+	if l.Config.Language != "en" || l.Config.Difficulty != domain.DifficultyHard || l.Config.Mode != domain.ModeHard ||
+		l.Config.Category != "animals" || !l.Config.Adaptive {
+		t.Errorf("Config = %+v, changed by a rejected START_GAME", l.Config)
+	}
+}
+
+func TestStartGameWithKeepsCategory(t *testing.T) {
+	h := NewHub()
+	l, _ := h.CreateLobby("category", nil)
+	for _, id := range []string{"p1", "p2", "p3"} {
+		l.AddPlayerSafe(&domain.Player{ID: id, Name: id})
+	}
+	l.StartGameWith(context.Background(), GameSettings{Mode: domain.ModeHard, Category: "Animals", Language: "en"})
+	l.ResetGame()
+
+	// No category: the previous one, in the new language
+	if err := l.StartGameWith(context.Background(), GameSettings{Mode: domain.ModeHard, Language: "es"}); err != nil {
+		t.Fatal(err)
+	}
+	if l.Config.Category != "animals" || l.pairCategory.Name != "Animales" {
+		t.Errorf("Category = %q, drawn from %+v; want the Spanish animals", l.Config.Category, l.pairCategory)
+	}
+}
@@ -0,0 +1,71 @@
+package game
+
+import (
+	"impostor/internal/domain"
+	"testing"
+)
+
+func TestEasyHintCard(t *testing.T) {
+	h := NewHub()
+	l, _ := h.CreateLobby("hint", nil)
+	l.Config.Language = "en"
+	l.Config.Mode = domain.ModeEasyHint
+	l.AddPlayerSafe(&domain.Player{ID: "p1", Name: "Ann", Role: domain.RoleImpostor})
+	l.AddPlayerSafe(&domain.Player{ID: "p2", Name: "Ana", Role: domain.RoleImpostor, Language: "es"})
+	l.AddPlayerSafe(&domain.Player{ID: "p3", Name: "Bob", Role: domain.RoleCivilian})
+
+	animals, _ := GetCategoryByID("animals", "en")
+	l.pairCategory = CategoryRef{ID: animals.ID, Name: animals.Name}
+	l.pairLanguage = "en"
+	dog := animals.Pairs[0]
+
+	card := l.GetCardForPlayer("p1", dog)
+	if card.DisplayedWord != "YOU ARE THE IMPOSTOR" || card.Category != "Animals" || card.Hint != dog.Hint || card.Hint == "" {
+		t.Errorf("impostor card = %+v, want the category and the hint of %s", card, dog.Real)
+	}
+	card = l.GetCardForPlayer("p2", dog)
+	if card.DisplayedWord != "ERES EL IMPOSTOR" || card.Category != "Animales" || card.Hint != "Tiene cuatro patas y cola" {
+		t.Errorf("Spanish impostor card = %+v, want the Spanish category and hint", card)
+	}
+	if card := l.GetCardForPlayer("p3", dog); card.DisplayedWord != "Dog" || card.Hint != "" || card.Category != "" {
+		t.Errorf("civilian card = %+v, want only the real word", card)
+	}
+
+	// Custom categories keep their name and pairs without a hint give none
+	l.pairCategory = CategoryRef{Name: "Office"}
+	card = l.GetCardForPlayer("p2", domain.WordPair{Real: "Stapler", Trap: "Hole Punch"})
+	if card.Category != "Office" || card.Hint != "" {
+		t.Errorf("custom category card = %+v, want category Office and no hint", card)
+	}
+}
+
+func TestFinishedRevealsWords(t *testing.T) {
+	h := NewHub()
+	l, _ := h.CreateLobby("reveal", nil)
+	l.Config.Language = "en"
+	l.AddPlayerSafe(&domain.Player{ID: "p1", Name: "Ann", Role: domain.RoleImpostor})
+	l.AddPlayerSafe(&domain.Player{ID: "p2", Name: "Ana", Role: domain.RoleCivilian, Language: "es"})
+	en, es := &fakeClient{}, &fakeClient{}
+	l.RegisterClient("p1", en)
+	l.RegisterClient("p2", es)
+
+	animals, _ := GetCategoryByID("animals", "en")
+	l.pairCategory = CategoryRef{ID: animals.ID, Name: animals.Name}
+	l.pairLanguage = "en"
+	l.CurrentPair = animals.Pairs[0]
+
+	l.finishGame("p1")
+
+	for _, tc := range []struct {
+		client            *fakeClient
+		real, trap, descr string
+	}{
+		{en, "Dog", "Wolf", animals.Pairs[0].Description},
+		{es, "Perro", "Lobo", "Cánido doméstico que se tiene como mascota o animal de trabajo."},
+	} {
+		msg := tc.client.sent[len(tc.client.sent)-1].(map[string]interface{})
+		if msg["real"] != tc.real || msg["trap"] != tc.trap || msg["description"] != tc.descr {
+			t.Errorf("FINISHED = %v, want %s/%s and %q", msg, tc.real, tc.trap, tc.descr)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package game
 import (
 	"impostor/internal/domain"
 	"sync"
+	"time"
 )
 
 // Hub manages the global state of all active lobbies.
@@ -11,23 +12,43 @@ import (
 type Hub struct {
 	lobbies map[string]*Lobby
 	mu      sync.RWMutex // Protects the lobbies map
+
+	limits   Limits         // See capacity.go
+	reaped   reaperCounters // Updated by the background reaper
+	infinite WordProvider   // Handed to new lobbies (see provider.go)
+
+	contentFilter     *ContentFilter // Handed to new lobbies with the mode (see content_filter.go)
+	contentFilterMode domain.ContentFilter
+
+	onRemove func(id string) // Optional, called after a lobby leaves the map
 }
 
 // NewHub creates a new Hub instance.
 func NewHub() *Hub {
 	return &Hub{
 		lobbies: make(map[string]*Lobby),
+		limits:  DefaultLimits(),
 	}
 }
 
 // CreateLobby initializes a new lobby safely.
-func (h *Hub) CreateLobby(id string, host *domain.Player) *Lobby {
+// Returns ErrTooManyLobbies when the server-wide cap is reached.
+func (h *Hub) CreateLobby(id string, host *domain.Player) (*Lobby, error) {
 	h.mu.Lock() // WRITE LOCK: No one else can read or write to the map
 	defer h.mu.Unlock()
 
+	if h.limits.MaxLobbies > 0 && len(h.lobbies) >= h.limits.MaxLobbies {
+		return nil, ErrTooManyLobbies
+	}
+
 	l := NewLobby(id, host)
+	l.Config.MaxPlayers = h.limits.DefaultMaxPlayers
+	l.maxPlayersCeiling = h.limits.MaxPlayersCeiling
+	l.infinite = h.infinite
+	l.contentFilter = h.contentFilter
+	l.Config.ContentFilter = h.contentFilterMode
 	h.lobbies[id] = l
-	return l
+	return l, nil
 }
 
 // GetLobby retrieves a lobby by ID safely.
@@ -39,12 +60,56 @@ func (h *Hub) GetLobby(id string) (*Lobby, bool) {
 	return l, ok
 }
 
+// ListLobbies returns a snapshot of all active lobbies.
+func (h *Hub) ListLobbies() []*Lobby {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	lobbies := make([]*Lobby, 0, len(h.lobbies))
+	for _, l := range h.lobbies {
+		lobbies = append(lobbies, l)
+	}
+	return lobbies
+}
+
+// CloseLobby removes a lobby and disconnects its players with the given reason.
+// Returns false if the lobby does not exist.
+func (h *Hub) CloseLobby(id, reason string) bool {
+	h.mu.Lock()
+	l, ok := h.lobbies[id]
+	delete(h.lobbies, id)
+	h.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+	h.removed(id)
+	l.Close(reason)
+	return true
+}
+
 // DeleteLobby removes a lobby when empty or finished.
 func (h *Hub) DeleteLobby(id string) {
 	h.mu.Lock() // WRITE LOCK
-	defer h.mu.Unlock()
-
+	_, ok := h.lobbies[id]
 	delete(h.lobbies, id)
+	h.mu.Unlock()
+
+	if ok {
+		h.removed(id)
+	}
+}
+
+// SetRemoveHook registers a callback invoked whenever a lobby is removed from the Hub,
+// whether it was deleted, closed or reaped. Must be set before the Hub is used.
+func (h *Hub) SetRemoveHook(fn func(id string)) {
+	h.onRemove = fn
+}
+
+func (h *Hub) removed(id string) {
+	if h.onRemove != nil {
+		h.onRemove(id)
+	}
 }
 
 // Lobby represents a specific match and its players.
@@ -56,6 +121,34 @@ type Lobby struct {
 	Votes   map[string]string        // VoterID -> TargetID
 	Config  domain.LobbyConfig
 	State   domain.LobbyState
+
+	// CurrentPair is the word pair of the match in progress (secret, never broadcast).
+	CurrentPair  domain.WordPair
+	pairCategory CategoryRef    // Category CurrentPair was drawn from; no ID if it can't be translated (see translate.go)
+	pairLanguage string         // Language CurrentPair was drawn in, the one it is translated from
+	pairVotes    map[string]int // PlayerID -> rating of CurrentPair once the game is finished (see ratings.go)
+
+	// CustomCategories are uploaded by the leader and only visible in this lobby (see custom_category.go).
+	CustomCategories map[string]domain.Category
+
+	// decks deal the pairs of each category without repeats, across games (see deck.go).
+	decks map[string]*Deck
+
+	// recentWins records whether civilians won each of the last games, for Config.Adaptive (see adaptive.go).
+	recentWins []bool
+
+	// Bookkeeping for the reaper (see reaper.go)
+	CreatedAt    time.Time
+	LastActivity time.Time
+	FinishedAt   time.Time
+	joined       bool // True once any player has connected
+
+	kicked map[string]bool   // IDs of the players kicked out, who can't join again (see lobby_info.go)
+	tokens map[string]string // PlayerID -> secret token, known only to that player (see session.go)
+
+	maxPlayersCeiling int            // Upper bound for Config.MaxPlayers, set by the Hub
+	infinite          WordProvider   // Draws the ✨ Infinite pairs, set by the Hub; nil for local words only
+	contentFilter     *ContentFilter // Blocklists for Config.ContentFilter, set by the Hub
 	
 	// Mutex to protect the Lobby's internal state (separate from Hub)
 	// This allows actions in Lobby A not to block Lobby B.
@@ -67,24 +160,71 @@ func NewLobby(id string, host *domain.Player) *Lobby {
 	if host != nil {
 		players[host.ID] = host
 	}
+	now := time.Now()
 	return &Lobby{
-		ID:      id,
-		Players: players,
-		Votes:   make(map[string]string),
-		State:   domain.StateWaiting,
+		ID:           id,
+		Players:      players,
+		Votes:        make(map[string]string),
+		State:        domain.StateWaiting,
+		CreatedAt:    now,
+		LastActivity: now,
+		joined:       host != nil,
 	}
 }
 
 // AddPlayerSafe adds a player and returns true if it was the first player (Leader).
-func (l *Lobby) AddPlayerSafe(p *domain.Player) bool {
+// Returns ErrLobbyFull if the lobby reached its capacity. Reconnecting players are always accepted,
+// unless they were kicked out (ErrPlayerKicked).
+// The name goes through the content filter: it is masked, or refused with ErrContentRejected.
+func (l *Lobby) AddPlayerSafe(p *domain.Player) (bool, error) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	
+
+	if l.kicked[p.ID] {
+		return false, ErrPlayerKicked
+	}
+
+	name, err := l.screen(p.Name, p.Language)
+	if err != nil {
+		return false, err
+	}
+	p.Name = name
+
+	previous, rejoining := l.Players[p.ID]
+	if !rejoining && l.Config.MaxPlayers > 0 && len(l.Players) >= l.Config.MaxPlayers {
+		return false, ErrLobbyFull
+	}
+	if rejoining && previous.IsLeader {
+		p.IsLeader = true // A leader who reconnects keeps the lead
+	}
+
 	isFirst := len(l.Players) == 0
 	if isFirst {
 		p.IsLeader = true
 	}
 	
 	l.Players[p.ID] = p
-	return isFirst
+	l.joined = true
+	l.touch()
+	return isFirst, nil
+}
+
+// HasPlayer reports whether the player is currently in the lobby.
+func (l *Lobby) HasPlayer(playerID string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	_, ok := l.Players[playerID]
+	return ok
+}
+
+// Touch records activity on the lobby so the reaper does not consider it idle.
+func (l *Lobby) Touch() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.touch()
+}
+
+// touch is the lock-free variant of Touch. Caller must hold the write lock.
+func (l *Lobby) touch() {
+	l.LastActivity = time.Now()
 }
@@ -0,0 +1,66 @@
+package game
+
+import (
+	"fmt"
+	"impostor/internal/domain"
+	"strings"
+	"unicode"
+)
+
+// Slugify turns a name into an ID: lowercase letters and digits separated by dashes ("Ice Cream" -> "ice-cream").
+func Slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return b.String()
+}
+
+// assignThemeIDs derives missing Infinite theme IDs from their names, like assignIDs for categories.
+func assignThemeIDs(themes []domain.InfiniteTheme) {
+	for i := range themes {
+		if themes[i].ID == "" {
+			themes[i].ID = Slugify(themes[i].Name)
+		}
+	}
+}
+
+// assignIDs fills in missing IDs, derived from the names and words: dictionaries written without IDs
+// keep working, but their categories and pairs are only linked across languages when IDs are given.
+// Generated pair IDs get a numeric suffix if needed to stay unique within their category.
+func assignIDs(categories []domain.Category) {
+	for i := range categories {
+		cat := &categories[i]
+		if cat.ID == "" {
+			cat.ID = Slugify(cat.Name)
+		}
+
+		taken := make(map[string]bool, len(cat.Pairs))
+		for _, p := range cat.Pairs {
+			if p.ID != "" {
+				taken[p.ID] = true
+			}
+		}
+		for j := range cat.Pairs {
+			p := &cat.Pairs[j]
+			if p.ID != "" {
+				continue
+			}
+			id := Slugify(p.Real + " " + p.Trap)
+			for n := 2; taken[id]; n++ {
+				id = fmt.Sprintf("%s-%d", Slugify(p.Real+" "+p.Trap), n)
+			}
+			p.ID = id
+			taken[id] = true
+		}
+	}
+}
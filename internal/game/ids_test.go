@@ -0,0 +1,60 @@
+package game
+
+import (
+	"impostor/internal/domain"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Ice Cream":           "ice-cream",
+		"  Harry  Potter! ":   "harry-potter",
+		"Côtelette de porc":   "côtelette-de-porc",
+		"McDonald's / Burger": "mcdonald-s-burger",
+	}
+	for in, want := range tests {
+		if got := Slugify(in); got != want {
+			t.Errorf("Slugify(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestAssignIDs(t *testing.T) {
+	categories := []domain.Category{{Name: "Office", Pairs: []domain.WordPair{
+		{Real: "Pen", Trap: "Pencil"},
+		{Real: "Pen", Trap: "Pencil"},
+		{ID: "kept", Real: "Desk", Trap: "Table"},
+	}}}
+	assignIDs(categories)
+
+	cat := categories[0]
+	if cat.ID != "office" || cat.Pairs[0].ID != "pen-pencil" || cat.Pairs[1].ID != "pen-pencil-2" || cat.Pairs[2].ID != "kept" {
+		t.Errorf("assignIDs() = %+v", cat)
+	}
+}
+
+func TestResolveCategoryAcrossLanguages(t *testing.T) {
+	tests := []struct {
+		ref, lang, want string
+	}{
+		{"animals", "fr", "Animaux"},
+		{"Animals", "es", "Animales"}, // English name, Spanish lobby
+		{"Comida", "de", "Essen"},
+		{"Animales", "es", "Animales"},
+		{InfiniteCategory, "es", InfiniteCategory},
+		{InfiniteCategoryID, "en", InfiniteCategory},
+		{"unknown", "es", "General"},
+	}
+	for _, tt := range tests {
+		if got := ResolveCategory(tt.ref, tt.lang).Name; got != tt.want {
+			t.Errorf("ResolveCategory(%q, %q) = %q, want %q", tt.ref, tt.lang, got, tt.want)
+		}
+	}
+
+	// Translated pairs share their ID
+	en, _ := GetCategoryByID("animals", "en")
+	es, _ := GetCategoryByID("animals", "es")
+	if en.Pairs[0].ID == "" || en.Pairs[0].ID != es.Pairs[0].ID {
+		t.Errorf("pair IDs should link translations, got %q and %q", en.Pairs[0].ID, es.Pairs[0].ID)
+	}
+}
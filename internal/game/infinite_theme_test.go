@@ -0,0 +1,163 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"impostor/internal/domain"
+	"impostor/internal/game/datamusetest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInfiniteThemeCategories(t *testing.T) {
+	if names := GetAllCategoryNames("en"); !slices.Contains(names, "✨ Infinite: Animals") || names[0] != InfiniteCategory {
+		t.Errorf("English categories = %v, want ✨ Infinite first and the Animals theme", names)
+	}
+	if refs := GetAllCategoryRefs("es"); !slices.Contains(refs, CategoryRef{ID: "infinite-animals", Name: "✨ Infinite: Animales"}) {
+		t.Errorf("Spanish categories = %v, want the Animales theme", refs)
+	}
+	// French has its own words but no themes: no English themes either
+	for _, name := range GetAllCategoryNames("fr") {
+		if name != InfiniteCategory && IsInfiniteCategory(name) {
+			t.Errorf("French categories list %q", name)
+		}
+	}
+
+	// Themes are translated through their ID, like categories
+	if got := ResolveCategory("✨ Infinite: Animals", "es"); got.ID != "infinite-animals" || got.Name != "✨ Infinite: Animales" {
+		t.Errorf("ResolveCategory() = %+v", got)
+	}
+	theme, ok := InfiniteThemeOf(ResolveCategory("infinite-food", "en"), "en")
+	if !ok || theme.Name != "Food" || len(theme.Seeds) == 0 || len(theme.Topics) == 0 {
+		t.Errorf("InfiniteThemeOf(infinite-food) = %+v, %v", theme, ok)
+	}
+	if _, ok := InfiniteThemeOf(infiniteCategory(), "en"); ok {
+		t.Error("the plain ✨ Infinite has no theme")
+	}
+}
+
+func TestLoadDictionaryDirThemes(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "en.json", `{"categories": [{"name": "Office", "pairs": [{"real": "Stapler", "trap": "Clip"}]}],
+		"infinite_themes": [{"name": "Office Life", "topics": ["office"], "seeds": ["desk", "printer"]}]}`)
+	writeFile(t, dir, "es.json", `{"categories": [{"name": "Oficina", "pairs": [{"real": "Grapadora", "trap": "Clip"}]}]}`)
+
+	d, err := LoadDictionaryDir(dir)
+	if err != nil {
+		t.Fatalf("LoadDictionaryDir() error = %v", err)
+	}
+	if themes := d.Themes("en"); len(themes) != 1 || themes[0].ID != "office-life" {
+		t.Errorf("en themes = %+v, want only Office Life", themes)
+	}
+	if themes := d.Themes("es"); len(themes) == 0 {
+		t.Error("a file without infinite_themes should keep the built-in themes")
+	}
+}
+
+func TestDatamuseThemedPair(t *testing.T) {
+	fake := datamusetest.NewServer(t)
+	client := NewDatamuseClient(fake.URL, nil)
+	theme := &domain.InfiniteTheme{ID: "birds", Name: "Birds", Topics: []string{"birds", "nature"}, Seeds: []string{"eagle"}}
+
+	pair, err := client.RandomThemedPair(context.Background(), "en", theme)
+	if err != nil || pair.Real != "Eagle" {
+		t.Fatalf("RandomThemedPair() = %+v, %v, want a pair for Eagle", pair, err)
+	}
+	if q := fake.Requests()[0]; q.Get("topics") != "birds nature" {
+		t.Errorf("query %v, want the theme topics", q)
+	}
+
+	// Without a theme, no topics
+	client.RandomPair(context.Background(), "en")
+	if q := fake.Requests()[1]; q.Has("topics") {
+		t.Errorf("query %v has topics", q)
+	}
+}
+
+func TestDatamusePoolThemes(t *testing.T) {
+	var calls atomic.Int32
+	p := newDatamusePool(2, countingFetch(&calls))
+	theme, _ := InfiniteThemeOf(ResolveCategory("infinite-animals", "en"), "en")
+
+	// Themes are only fetched once played
+	p.Prefetch("en")
+	waitForPool(t, p, "en", 2)
+	if n := p.Health().Pool["en/animals"]; n != 0 {
+		t.Errorf("themed pool prefetched with %d pairs", n)
+	}
+	if _, err := p.Draw(context.Background(), WordRequest{Language: "en", Theme: &theme}); !errors.Is(err, ErrNoWords) {
+		t.Errorf("first themed Draw() error = %v, want ErrNoWords", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for p.Health().Pool["en/animals"] < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("themed pool never filled: %v", p.Health().Pool)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	pair, err := p.Draw(context.Background(), WordRequest{Language: "en", Theme: &theme})
+	if err != nil || !strings.HasPrefix(pair.Real, "en/animals-") {
+		t.Errorf("themed Draw() = %+v, %v, want a pair of the animals pool", pair, err)
+	}
+	pair, err = p.Draw(context.Background(), WordRequest{Language: "en"})
+	if err != nil || strings.Contains(pair.Real, "/") {
+		t.Errorf("Draw() = %+v, %v, want a pair of the plain pool", pair, err)
+	}
+}
+
+func TestAssociationProviderThemes(t *testing.T) {
+	g, _ := ParseAssociations(strings.NewReader("eagle\ttrg\tnest\neagle\ttrg\tsky\nocean\ttrg\twave\n"))
+	p := NewAssociationProvider(map[string]*AssociationGraph{"en": g})
+
+	birds := &domain.InfiniteTheme{ID: "birds", Seeds: []string{"sparrow", "eagle"}}
+	for range 20 {
+		if pair, err := p.Draw(context.Background(), WordRequest{Language: "en", Theme: birds}); err != nil || pair.Real != "Eagle" {
+			t.Fatalf("themed Draw() = %+v, %v, want Eagle, the only seed of the theme in the graph", pair, err)
+		}
+	}
+	if _, err := p.Draw(context.Background(), WordRequest{Language: "en", Theme: &domain.InfiniteTheme{Seeds: []string{"sparrow"}}}); err == nil {
+		t.Error("a theme without seeds in the graph should have no pairs")
+	}
+}
+
+// themeProvider returns a pair named after the theme of the request.
+type themeProvider struct{}
+
+func (themeProvider) Draw(_ context.Context, req WordRequest) (domain.WordPair, error) {
+	if req.Theme == nil {
+		return domain.WordPair{}, ErrNoWords
+	}
+	return domain.WordPair{Real: req.Theme.Name, Trap: "Trap"}, nil
+}
+
+func TestThemedInfiniteGame(t *testing.T) {
+	for name, tc := range map[string]struct {
+		provider WordProvider
+		want     string // Category of the pair
+	}{
+		"provider":    {themeProvider{}, "infinite-animals"},
+		"no provider": {nil, "animals"}, // Falls back to the local category of the theme
+	} {
+		h := NewHub()
+		h.SetInfiniteProvider(tc.provider)
+		l, _ := h.CreateLobby("themed-"+name, nil)
+		l.Config.Language = "en"
+		for _, id := range []string{"p1", "p2", "p3"} {
+			l.AddPlayerSafe(&domain.Player{ID: id, Name: id})
+		}
+
+		if err := l.StartGame(context.Background(), ResolveCategory("✨ Infinite: Animals", "en"), domain.ModeHard); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if l.pairCategory.ID != tc.want {
+			t.Errorf("%s: drew %+v from %+v, want category %s", name, l.CurrentPair, l.pairCategory, tc.want)
+		}
+		if name == "provider" && l.CurrentPair.Real != "Animals" {
+			t.Errorf("%s: provider drew %+v without the theme", name, l.CurrentPair)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package game
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Language describes a supported locale: everything besides the dictionary that changes with the language.
+type Language struct {
+	Code string `json:"code"` // BCP 47 style code, e.g. "pt" or "pt-BR"
+	Name string `json:"name"` // Native name, for the language picker
+
+	// Datamuse tells whether Datamuse has a vocabulary for the language (used by the Infinite category).
+	// DatamuseVocabulary is its "v" parameter, empty for the default English vocabulary.
+	Datamuse           bool     `json:"infinite"`
+	DatamuseVocabulary string   `json:"-"`
+	SeedWords          []string `json:"-"`
+
+	// ImpostorText is shown on the impostor's card in Normal mode.
+	ImpostorText string `json:"-"`
+}
+
+var (
+	languagesMu sync.RWMutex
+	languages   = make(map[string]Language)
+)
+
+func init() {
+	RegisterLanguage(Language{
+		Code:         "en",
+		Name:         "English",
+		Datamuse:     true,
+		SeedWords:    seedWordsEN,
+		ImpostorText: "YOU ARE THE IMPOSTOR",
+	})
+	RegisterLanguage(Language{
+		Code:               "es",
+		Name:               "Español",
+		Datamuse:           true,
+		DatamuseVocabulary: "es",
+		SeedWords:          seedWordsES,
+		ImpostorText:       "ERES EL IMPOSTOR",
+	})
+	RegisterLanguage(Language{
+		Code:         "fr",
+		Name:         "Français",
+		SeedWords:    seedWordsFR,
+		ImpostorText: "TU ES L'IMPOSTEUR",
+	})
+	RegisterLanguage(Language{
+		Code:         "de",
+		Name:         "Deutsch",
+		SeedWords:    seedWordsDE,
+		ImpostorText: "DU BIST DER IMPOSTOR",
+	})
+	RegisterLanguage(Language{
+		Code:         "pt",
+		Name:         "Português",
+		SeedWords:    seedWordsPT,
+		ImpostorText: "TU ÉS O IMPOSTOR",
+	})
+}
+
+// RegisterLanguage adds or replaces a language in the registry.
+func RegisterLanguage(lang Language) {
+	languagesMu.Lock()
+	defer languagesMu.Unlock()
+	languages[lang.Code] = lang
+}
+
+// LanguageChain returns the codes to try for a language, most specific first:
+// "pt-BR" gives ["pt-BR", "pt", "en"]. It always ends with DefaultLanguage.
+func LanguageChain(code string) []string {
+	chain := make([]string, 0, 3)
+	if code != "" {
+		chain = append(chain, code)
+		if base, _, ok := strings.Cut(code, "-"); ok {
+			chain = append(chain, base)
+		}
+	}
+	if !slices.Contains(chain, DefaultLanguage) {
+		chain = append(chain, DefaultLanguage)
+	}
+	return chain
+}
+
+// LookupLanguage returns the first registered language along the fallback chain of code.
+func LookupLanguage(code string) Language {
+	languagesMu.RLock()
+	defer languagesMu.RUnlock()
+	for _, c := range LanguageChain(code) {
+		if lang, ok := languages[c]; ok {
+			return lang
+		}
+	}
+	return languages[DefaultLanguage]
+}
+
+// AvailableLanguages lists the languages players can pick, sorted by code: the registered ones
+// plus any language only present in the dictionary (named after its code).
+func AvailableLanguages() []Language {
+	languagesMu.RLock()
+	defer languagesMu.RUnlock()
+
+	available := make([]Language, 0, len(languages))
+	for _, lang := range languages {
+		available = append(available, lang)
+	}
+	for _, code := range CurrentDictionary().Languages() {
+		if _, ok := languages[code]; !ok {
+			available = append(available, Language{Code: code, Name: code})
+		}
+	}
+	slices.SortFunc(available, func(a, b Language) int {
+		return strings.Compare(a.Code, b.Code)
+	})
+	return available
+}
@@ -0,0 +1,56 @@
+package game
+
+import (
+	"impostor/internal/domain"
+	"slices"
+	"testing"
+)
+
+func TestLanguageChain(t *testing.T) {
+	tests := []struct {
+		code string
+		want []string
+	}{
+		{"pt-BR", []string{"pt-BR", "pt", "en"}},
+		{"fr", []string{"fr", "en"}},
+		{"en", []string{"en"}},
+		{"en-GB", []string{"en-GB", "en"}},
+		{"", []string{"en"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := LanguageChain(tt.code); !slices.Equal(got, tt.want) {
+				t.Errorf("LanguageChain(%q) = %v, want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLanguageFallback(t *testing.T) {
+	if got := GetCategoryByName("Animais", "pt-BR"); got.Name != "Animais" {
+		t.Errorf("pt-BR should use the Portuguese dictionary, got %q", got.Name)
+	}
+	if got := GetAllCategoryNames("xx")[1]; got != "General" {
+		t.Errorf("unknown languages should use English, got %q", got)
+	}
+	if got := LookupLanguage("de-AT").Code; got != "de" {
+		t.Errorf("LookupLanguage(de-AT) = %q, want de", got)
+	}
+}
+
+func TestImpostorCardText(t *testing.T) {
+	h := NewHub()
+	l, _ := h.CreateLobby("cards", nil)
+	l.Players["p1"] = &domain.Player{ID: "p1", Role: domain.RoleImpostor}
+	l.Config.Mode = domain.ModeHard
+
+	l.Config.Language = "fr"
+	if got := l.GetCardForPlayer("p1", domain.WordPair{Real: "Chien", Trap: "Loup"}).DisplayedWord; got != "TU ES L'IMPOSTEUR" {
+		t.Errorf("French impostor card = %q", got)
+	}
+	l.Config.Language = "xx"
+	if got := l.GetCardForPlayer("p1", domain.WordPair{Real: "Dog", Trap: "Wolf"}).DisplayedWord; got != "YOU ARE THE IMPOSTOR" {
+		t.Errorf("unknown language impostor card = %q", got)
+	}
+}
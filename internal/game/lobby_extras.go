@@ -24,6 +24,7 @@ import (
 
 type NetworkClient interface {
 	WriteJSON(v any) error
+	Close() error
 }
 
 // Update Lobby struct in `hub.go` to include `Clients map[string]NetworkClient`
@@ -53,3 +54,39 @@ func (l *Lobby) Broadcast(msg any) {
 		}
 	}
 }
+
+// SendTo sends a message to a single player, if connected.
+// Used for replies that only concern the sender, like errors.
+func (l *Lobby) SendTo(playerID string, msg any) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	client, ok := l.Clients[playerID]
+	if !ok {
+		return
+	}
+	if err := client.WriteJSON(msg); err != nil {
+		log.Printf("Error sending to player %s: %v", playerID, err)
+	}
+}
+
+// Close notifies every connected client that the lobby is gone and drops their connections.
+// The read loops of the handlers will then fail and clean up after themselves.
+// The connections are closed after releasing the lock, as their disconnect handlers take it too.
+func (l *Lobby) Close(reason string) {
+	l.mu.Lock()
+	clients := l.Clients
+	l.Clients = make(map[string]NetworkClient)
+	l.mu.Unlock()
+
+	msg := map[string]interface{}{
+		"type":   "LOBBY_CLOSED",
+		"reason": reason,
+	}
+	for id, client := range clients {
+		if err := client.WriteJSON(msg); err != nil {
+			log.Printf("Error sending to player %s: %v", id, err)
+		}
+		client.Close()
+	}
+}
@@ -0,0 +1,115 @@
+package game
+
+import (
+	"errors"
+	"impostor/internal/domain"
+	"log"
+	"time"
+)
+
+// ErrPlayerKicked is returned when a player kicked out of a lobby tries to join it again.
+var ErrPlayerKicked = errors.New("player was kicked from this lobby")
+
+// LobbySummary is the operator-facing overview of a lobby.
+type LobbySummary struct {
+	ID           string            `json:"id"`
+	State        domain.LobbyState `json:"state"`
+	Mode         domain.GameMode   `json:"mode"`
+	Language     string            `json:"language"`
+	PlayerCount  int               `json:"player_count"`
+	AgeSecs      int64             `json:"age_secs"`
+	CreatedAt    time.Time         `json:"created_at"`
+	LastActivity time.Time         `json:"last_activity"`
+}
+
+// LobbyDetails extends LobbySummary with the players and, optionally, the secrets of the match.
+type LobbyDetails struct {
+	LobbySummary
+	Players []domain.Player   `json:"players"`
+	Votes   map[string]string `json:"votes"`
+
+	// Only filled when secrets are requested. Roles are in Players.
+	CurrentPair *domain.WordPair `json:"current_pair,omitempty"`
+}
+
+// Summary returns a snapshot of the lobby for listings.
+func (l *Lobby) Summary() LobbySummary {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.summary()
+}
+
+// summary is the lock-free variant of Summary. Caller must hold the lock.
+func (l *Lobby) summary() LobbySummary {
+	return LobbySummary{
+		ID:           l.ID,
+		State:        l.State,
+		Mode:         l.Config.Mode,
+		Language:     l.Config.Language,
+		PlayerCount:  len(l.Players),
+		AgeSecs:      int64(time.Since(l.CreatedAt).Seconds()),
+		CreatedAt:    l.CreatedAt,
+		LastActivity: l.LastActivity,
+	}
+}
+
+// Details returns a full snapshot of the lobby.
+// Roles and the current word pair are only included when includeSecrets is true.
+func (l *Lobby) Details(includeSecrets bool) LobbyDetails {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	details := LobbyDetails{
+		LobbySummary: l.summary(),
+		Players:      make([]domain.Player, 0, len(l.Players)),
+		Votes:        make(map[string]string, len(l.Votes)),
+	}
+	for _, p := range l.Players {
+		player := *p
+		if !includeSecrets {
+			player.Role = ""
+		}
+		details.Players = append(details.Players, player)
+	}
+	for voter, target := range l.Votes {
+		details.Votes[voter] = target
+	}
+	if includeSecrets && l.State != domain.StateWaiting {
+		pair := l.CurrentPair
+		details.CurrentPair = &pair
+	}
+	return details
+}
+
+// KickPlayer notifies the lobby that a player was removed and drops their connection.
+// The handler's read loop takes care of removing the player from the lobby, and the player
+// can't join it again. Returns false if the player is not connected.
+func (l *Lobby) KickPlayer(playerID, reason string) bool {
+	l.mu.Lock()
+	client, ok := l.Clients[playerID]
+	if !ok {
+		l.mu.Unlock()
+		return false
+	}
+
+	msg := map[string]interface{}{
+		"type":      "PLAYER_KICKED",
+		"player_id": playerID,
+		"reason":    reason,
+	}
+	l.broadcastInternal(msg)
+
+	if l.kicked == nil {
+		l.kicked = make(map[string]bool)
+	}
+	l.kicked[playerID] = true
+	delete(l.Clients, playerID)
+	l.touch()
+	l.mu.Unlock()
+
+	// Closed without the lock, which the disconnect handler takes
+	if err := client.Close(); err != nil {
+		log.Printf("Error closing connection of %s: %v", playerID, err)
+	}
+	return true
+}
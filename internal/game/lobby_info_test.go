@@ -0,0 +1,69 @@
+package game
+
+import (
+	"errors"
+	"impostor/internal/domain"
+	"testing"
+)
+
+func TestKickPlayer(t *testing.T) {
+	l := NewLobby("kick", nil)
+	for _, id := range []string{"p1", "p2"} {
+		l.AddPlayerSafe(&domain.Player{ID: id, Name: id})
+	}
+	leader, kicked := &fakeClient{}, &fakeClient{}
+	l.RegisterClient("p1", leader)
+	l.RegisterClient("p2", kicked)
+
+	if l.KickPlayer("stranger", "bye") {
+		t.Error("KickPlayer() of a player not connected should fail")
+	}
+	if !l.KickPlayer("p2", "Spamming") {
+		t.Fatal("KickPlayer() = false")
+	}
+	if !kicked.closed {
+		t.Error("the connection of the kicked player was not closed")
+	}
+	msg, _ := leader.sent[len(leader.sent)-1].(map[string]interface{})
+	if msg["type"] != "PLAYER_KICKED" || msg["player_id"] != "p2" || msg["reason"] != "Spamming" {
+		t.Errorf("last message = %v, want PLAYER_KICKED", msg)
+	}
+
+	// The read loop removes the player once the connection is closed, then they try again
+	l.RemovePlayer("p2")
+	if _, err := l.AddPlayerSafe(&domain.Player{ID: "p2", Name: "p2"}); !errors.Is(err, ErrPlayerKicked) {
+		t.Errorf("AddPlayerSafe() after a kick: err = %v, want ErrPlayerKicked", err)
+	}
+	if l.HasPlayer("p2") {
+		t.Error("the kicked player joined again")
+	}
+	if _, err := l.AddPlayerSafe(&domain.Player{ID: "p3", Name: "p3"}); err != nil {
+		t.Errorf("AddPlayerSafe() of another player: err = %v", err)
+	}
+}
+
+func TestKickPlayerDuringVote(t *testing.T) {
+	l := NewLobby("kick-vote", nil)
+	for _, id := range []string{"p1", "p2", "p3", "p4"} {
+		l.AddPlayerSafe(&domain.Player{ID: id, Name: id})
+		l.RegisterClient(id, &fakeClient{})
+	}
+	l.State = domain.StatePlaying
+
+	// p4 is voted against, then kicked out with their own vote
+	l.CastVote("p1", "p4")
+	l.CastVote("p4", "p2")
+	l.KickPlayer("p4", "Spamming")
+	l.RemovePlayer("p4")
+	if len(l.Votes) != 0 {
+		t.Errorf("Votes after the kick = %v, want none", l.Votes)
+	}
+
+	if err := l.CastVote("p2", "p4"); !errors.Is(err, ErrPlayerNotInLobby) {
+		t.Errorf("CastVote() against a kicked player: err = %v, want ErrPlayerNotInLobby", err)
+	}
+	l.CastVote("p2", "p3")
+	if l.State != domain.StateVoting {
+		t.Errorf("State = %s, the game ended with stale votes", l.State)
+	}
+}
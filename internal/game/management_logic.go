@@ -7,7 +7,23 @@ import "log"
 func (l *Lobby) RemovePlayer(playerID string) bool {
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	return l.removePlayer(playerID)
+}
 
+// Disconnect removes a player whose connection closed, as RemovePlayer, unless they already
+// reconnected with another client: they stay then, and left is false. A nil client matches any.
+func (l *Lobby) Disconnect(playerID string, client NetworkClient) (left, isEmpty bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if current, ok := l.Clients[playerID]; ok && client != nil && current != client {
+		return false, false
+	}
+	return true, l.removePlayer(playerID)
+}
+
+// removePlayer is the lock-free variant of RemovePlayer. Caller must hold the write lock.
+func (l *Lobby) removePlayer(playerID string) bool {
 	p, exists := l.Players[playerID]
 	if !exists {
 		return len(l.Players) == 0
@@ -16,6 +32,15 @@ func (l *Lobby) RemovePlayer(playerID string) bool {
 	wasLeader := p.IsLeader
 	delete(l.Players, playerID)
 	delete(l.Clients, playerID)
+	delete(l.tokens, playerID)
+	// Their votes, and the votes against them, no longer count
+	delete(l.Votes, playerID)
+	for voterID, targetID := range l.Votes {
+		if targetID == playerID {
+			delete(l.Votes, voterID)
+		}
+	}
+	l.touch()
 
 	if len(l.Players) == 0 {
 		return true // Lobby is empty
@@ -51,13 +76,16 @@ func (l *Lobby) BroadcastPlayerList() {
 			"id":        p.ID,
 			"name":      p.Name,
 			"is_leader": p.IsLeader,
+			"language":  p.Language,
 		})
 	}
+	maxPlayers := l.Config.MaxPlayers
 	l.mu.RUnlock()
 
 	msg := map[string]any{
-		"type":    "PLAYER_LIST", // Frontend should handle this to replace the list
-		"players": players,
+		"type":        "PLAYER_LIST", // Frontend should handle this to replace the list
+		"players":     players,
+		"max_players": maxPlayers,
 	}
 
 	l.Broadcast(msg)
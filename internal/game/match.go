@@ -1,17 +1,59 @@
 package game
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"impostor/internal/domain"
 	"log"
 	"math/rand"
+	"time"
 )
 
+// ErrGameInProgress is returned when starting a game, or changing its settings, while one is running.
+var ErrGameInProgress = errors.New("lobby is already in game")
+
+// GameSettings are the options of a START_GAME command. They are only applied to the lobby config
+// when the game starts, so a START_GAME rejected mid-game changes nothing.
+type GameSettings struct {
+	Mode       domain.GameMode
+	Category   string // Name or ID, custom categories of the lobby first; empty keeps the previous one
+	Language   string
+	Difficulty domain.Difficulty // Empty means any
+	Adaptive   bool              // Adjust Difficulty to the winners of the recent games
+}
+
+// StartGameWith applies the settings chosen by the leader, then starts a match like StartGame.
+func (l *Lobby) StartGameWith(ctx context.Context, settings GameSettings) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.State != domain.StateWaiting {
+		return ErrGameInProgress
+	}
+	l.Config.Language = settings.Language
+	l.Config.Difficulty = settings.Difficulty
+	l.Config.Adaptive = settings.Adaptive
+
+	// Without a category, the previous one is kept, even if the language changed
+	ref := settings.Category
+	if ref == "" {
+		ref = l.Config.Category
+	}
+	category := l.resolveCategory(ref, settings.Language)
+	l.Config.Category = category.ID
+	return l.startGame(ctx, category, settings.Mode)
+}
+
 // StartGame initializes a new match.
-func (l *Lobby) StartGame(category domain.Category, mode domain.GameMode) error {
+func (l *Lobby) StartGame(ctx context.Context, category domain.Category, mode domain.GameMode) error {
 	l.mu.Lock() // We need global lock to set state
 	defer l.mu.Unlock()
+	return l.startGame(ctx, category, mode)
+}
 
+// startGame is the lock-free variant of StartGame. Caller must hold the write lock.
+func (l *Lobby) startGame(ctx context.Context, category domain.Category, mode domain.GameMode) error {
 	if len(l.Players) < 3 {
 		// return nil // Allow for testing with fewer players? No, stick to rules or user preference.
 		// For MVP testing, let's allow it, OR just return error.
@@ -19,16 +61,19 @@ func (l *Lobby) StartGame(category domain.Category, mode domain.GameMode) error
 	}
 
 	if l.State != domain.StateWaiting {
-		return fmt.Errorf("lobby is already in game")
+		return ErrGameInProgress
 	}
 
 	l.Config.Mode = mode // Store the mode
+	l.touch()
 	
 	// 1. Assign Roles
 	l.assignRoles()
 
 	// 2. Select Word Pair
-	pair := l.selectRandomWordPair(category)
+	pair := l.selectRandomWordPair(ctx, category)
+	l.CurrentPair = pair
+	l.pairVotes = nil
 
 	// 3. Distribute Cards (Broadcast to clients)
 	l.broadcastStartWithPair(pair)
@@ -51,6 +96,10 @@ func (l *Lobby) broadcastStartWithPair(pair domain.WordPair) {
 			"role": string(p.Role),
 			"displayed_word": card.DisplayedWord,
 		}
+		if card.Category != "" {
+			msg["category"] = card.Category
+			msg["hint"] = card.Hint
+		}
 
 		if err := client.WriteJSON(msg); err != nil {
 			log.Printf("Error sending start to %s: %v", id, err)
@@ -82,27 +131,54 @@ func (l *Lobby) assignRoles() {
 	}
 }
 
-func (l *Lobby) selectRandomWordPair(cat domain.Category) domain.WordPair {
-	if cat.Name == "✨ Infinite" {
+func (l *Lobby) selectRandomWordPair(ctx context.Context, cat domain.Category) domain.WordPair {
+	if IsInfiniteCategory(cat.Name) {
 		language := l.Config.Language
-		if language == "" {
-			language = "en" // Default to English
+		req := WordRequest{Category: cat, Language: language, Difficulty: l.drawDifficulty()}
+		if theme, ok := InfiniteThemeOf(cat, language); ok {
+			req.Theme = &theme
+		}
+		if l.infinite != nil {
+			// The provider never waits on the network, we hold the lobby lock
+			pair, err := drawRated(ctx, l.infinite, req)
+			if err == nil {
+				l.pairCategory = CategoryRef{ID: cat.ID, Name: cat.Name}
+				l.pairLanguage = language
+				return pair
+			}
+			log.Printf("No %s pair for %s (fallback to local): %v", cat.Name, language, err)
 		}
-		pair, err := FetchRandomPairFromAPI(language)
-		if err == nil {
-			return pair
+		// Fallback to the local category of the theme ("animals" for "infinite-animals"), or a random one
+		if req.Theme != nil {
+			if local, ok := GetCategoryByID(req.Theme.ID, language); ok && !IsInfiniteCategory(local.Name) {
+				return l.selectRandomWordPair(ctx, local)
+			}
 		}
-		log.Printf("API Error (fallback to local): %v", err)
-        // Fallback to a random category from defaults if API fails
-        randomCat := GetCategoryByName(DefaultCategories[rand.Intn(len(DefaultCategories))].Name, language)
-        return l.selectRandomWordPair(randomCat)
+		return l.selectRandomWordPair(ctx, GetRandomCategory(language))
 	}
 
-	if len(cat.Pairs) == 0 {
+	if _, custom := l.CustomCategories[cat.Name]; custom {
+		cat.ID = "" // Custom category IDs may clash with the dictionary's, their pairs are not rated
+	}
+	if cat.ID == CommunityCategoryID {
+		// Community pairs come from the votes of other lobbies, they are screened whatever the lobby mode
+		cat.Pairs = l.contentFilter.CleanPairs(cat.Pairs, l.Config.Language)
+		if len(cat.Pairs) == 0 {
+			return l.selectRandomWordPair(ctx, GetRandomCategory(l.Config.Language))
+		}
+	}
+	pair, err := DictionaryProvider{}.Draw(ctx, WordRequest{
+		Category:   cat,
+		Language:   l.Config.Language,
+		Difficulty: l.drawDifficulty(),
+		Deck:       l.deck(cat.Name, l.Config.Language),
+	})
+	if err != nil {
 		return domain.WordPair{Real: "Error", Trap: "Error"}
 	}
-	idx := rand.Intn(len(cat.Pairs))
-	return cat.Pairs[idx]
+	l.pairCategory = CategoryRef{ID: cat.ID, Name: cat.Name}
+	l.pairLanguage = l.Config.Language
+	return pair
 }
 
 func (l *Lobby) distributeCards(pair domain.WordPair) {
@@ -125,6 +201,10 @@ func (l *Lobby) GetCardForPlayer(playerID string, pair domain.WordPair) domain.C
 		return domain.Card{}
 	}
 
+	// Each player gets the pair in their own language
+	language := l.playerLanguage(p)
+	pair = l.translatePair(pair, language)
+
 	if p.Role == domain.RoleCivilian {
 		return domain.Card{
 			DisplayedWord: pair.Real,
@@ -140,8 +220,17 @@ func (l *Lobby) GetCardForPlayer(playerID string, pair domain.WordPair) domain.C
 		}
 	}
 
+	if l.Config.Mode == domain.ModeEasyHint {
+		return domain.Card{
+			DisplayedWord: LookupLanguage(language).ImpostorText,
+			IsImpostor:    true,
+			Category:      l.categoryName(language),
+			Hint:          pair.Hint,
+		}
+	}
+
 	return domain.Card{
-		DisplayedWord: "YOU ARE THE IMPOSTOR",
+		DisplayedWord: LookupLanguage(language).ImpostorText,
 		IsImpostor:    true,
 	}
 }
@@ -154,8 +243,12 @@ func (l *Lobby) CastVote(voterID, targetID string) error {
 	if l.State != domain.StatePlaying && l.State != domain.StateVoting {
 		return fmt.Errorf("voting not allowed now")
 	}
+	if _, ok := l.Players[targetID]; !ok {
+		return ErrPlayerNotInLobby
+	}
 	
 	l.State = domain.StateVoting // Ensure we are in voting mode
+	l.touch()
 	
 	if currentTarget, ok := l.Votes[voterID]; ok && currentTarget == targetID {
 		// Toggle off (remove vote)
@@ -198,7 +291,10 @@ func (l *Lobby) CastVote(voterID, targetID string) error {
 }
 
 func (l *Lobby) finishGame(kickedID string) {
-	kickedPlayer := l.Players[kickedID]
+	kickedPlayer, ok := l.Players[kickedID]
+	if !ok {
+		return // Left the lobby meanwhile
+	}
 	
 	// Determine Winner
 	var winner string // "CIVILIANS" or "IMPOSTOR"
@@ -220,16 +316,32 @@ func (l *Lobby) finishGame(kickedID string) {
 		})
 	}
 	
-	msg := map[string]interface{}{
-		"status":   "FINISHED",
-		"winner":   winner,
-		"kicked":   kickedPlayer.Name,
-		"role_was": string(kickedPlayer.Role),
-		"reveal":   allPlayers,
-	}
-	
 	l.State = domain.StateFinished
-	l.broadcastInternal(msg)
+	l.FinishedAt = time.Now()
+	l.recordWinner(winner == "CIVILIANS")
+
+	// The words are revealed in each player's language, so everyone gets their own message
+	for id, client := range l.Clients {
+		pair := l.CurrentPair
+		if p, ok := l.Players[id]; ok {
+			pair = l.translatePair(pair, l.playerLanguage(p))
+		}
+
+		msg := map[string]interface{}{
+			"status":      "FINISHED",
+			"winner":      winner,
+			"kicked":      kickedPlayer.Name,
+			"role_was":    string(kickedPlayer.Role),
+			"reveal":      allPlayers,
+			"difficulty":  DifficultyOf(l.CurrentPair),
+			"real":        pair.Real,
+			"trap":        pair.Trap,
+			"description": pair.Description,
+		}
+		if err := client.WriteJSON(msg); err != nil {
+			log.Printf("Error sending to player %s: %v", id, err)
+		}
+	}
 	
 	// Reset Game?
 	l.Votes = make(map[string]string)
@@ -241,6 +353,8 @@ func (l *Lobby) ResetGame() {
 
 	l.State = domain.StateWaiting
 	l.Votes = make(map[string]string)
+	l.FinishedAt = time.Time{}
+	l.touch()
 
 	msg := map[string]interface{}{
 		"type":   "GAME_RESET",
@@ -0,0 +1,107 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"impostor/internal/domain"
+	"slices"
+)
+
+// ErrNoWords is returned by a WordProvider that has no pair ready for a request.
+var ErrNoWords = errors.New("no word pairs available")
+
+// WordRequest describes the pair a lobby needs.
+type WordRequest struct {
+	Category   domain.Category
+	Language   string
+	Difficulty domain.Difficulty     // Empty means any
+	Deck       *Deck                 // Pairs already dealt by the lobby; nil deals from a fresh deck
+	Theme      *domain.InfiniteTheme // Theme of an Infinite category, nil for the plain ✨ Infinite
+}
+
+// WordProvider draws word pairs for the lobbies.
+// Draw is called with the lobby lock held, so it must never wait on the network:
+// a provider without a pair ready returns ErrNoWords and the lobby falls back to local words.
+// ctx is the context of the command that started the game; once it is done Draw returns its error.
+type WordProvider interface {
+	Draw(ctx context.Context, req WordRequest) (domain.WordPair, error)
+}
+
+// LanguageProvider is implemented by the WordProviders that only have pairs for some languages.
+type LanguageProvider interface {
+	Serves(language string) bool
+}
+
+// DictionaryProvider draws from the pairs of the requested category, through the deck of the request.
+// Pairs excluded by the downvotes of the players are skipped, unless the category has nothing else.
+type DictionaryProvider struct{}
+
+func (DictionaryProvider) Draw(_ context.Context, req WordRequest) (domain.WordPair, error) {
+	if len(req.Category.Pairs) == 0 {
+		return domain.WordPair{}, ErrNoWords
+	}
+	deck := req.Deck
+	if deck == nil {
+		deck = NewDeck()
+	}
+	pairs := filterExcluded(req.Category.Pairs, req.Language, req.Category.ID)
+	pair, _ := deck.Draw(filterByDifficulty(pairs, req.Difficulty))
+	return pair, nil
+}
+
+// SetInfiniteProvider sets the provider of the ✨ Infinite category for the lobbies created afterwards.
+// Without one, Infinite games draw from a random local category.
+func (h *Hub) SetInfiniteProvider(p WordProvider) {
+	h.infinite = p
+}
+
+// InfiniteServes reports whether the ✨ Infinite provider has pairs for a language. Providers that
+// don't implement LanguageProvider are assumed to serve every language.
+func (h *Hub) InfiniteServes(language string) bool {
+	if h.infinite == nil {
+		return false
+	}
+	if p, ok := h.infinite.(LanguageProvider); ok {
+		return p.Serves(language)
+	}
+	return true
+}
+
+// CategoryRefs is GetAllCategoryRefs without ✨ Infinite and its themes when no provider serves
+// the language, so players don't pick a category that always falls back to local words.
+func (h *Hub) CategoryRefs(language string) []CategoryRef {
+	refs := GetAllCategoryRefs(language)
+	if h.InfiniteServes(language) {
+		return refs
+	}
+	return slices.DeleteFunc(refs, func(ref CategoryRef) bool {
+		return IsInfiniteCategory(ref.Name)
+	})
+}
+
+// FallbackProviders tries each provider in turn and returns the first pair drawn.
+type FallbackProviders []WordProvider
+
+func (f FallbackProviders) Draw(ctx context.Context, req WordRequest) (domain.WordPair, error) {
+	err := ErrNoWords
+	for _, p := range f {
+		if ctx.Err() != nil {
+			return domain.WordPair{}, ctx.Err()
+		}
+		var pair domain.WordPair
+		if pair, err = p.Draw(ctx, req); err == nil {
+			return pair, nil
+		}
+	}
+	return domain.WordPair{}, err
+}
+
+// Serves reports whether any of the providers serves the language.
+func (f FallbackProviders) Serves(language string) bool {
+	for _, p := range f {
+		if lp, ok := p.(LanguageProvider); !ok || lp.Serves(language) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"impostor/internal/domain"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDictionaryProvider(t *testing.T) {
+	cat := domain.Category{Name: "Animals", Pairs: []domain.WordPair{
+		{Real: "Dog", Trap: "Wolf", Difficulty: domain.DifficultyMedium},
+		{Real: "Frog", Trap: "Toad", Difficulty: domain.DifficultyHard},
+	}}
+
+	deck := NewDeck()
+	seen := make(map[string]bool)
+	for range cat.Pairs {
+		pair, err := DictionaryProvider{}.Draw(context.Background(), WordRequest{Category: cat, Deck: deck})
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen[pair.Real] = true
+	}
+	if len(seen) != 2 {
+		t.Errorf("drawing through a deck repeated a pair: %v", seen)
+	}
+
+	if pair, _ := (DictionaryProvider{}).Draw(context.Background(), WordRequest{Category: cat, Difficulty: domain.DifficultyHard}); pair.Real != "Frog" {
+		t.Errorf("hard draw = %+v, want Frog", pair)
+	}
+	if _, err := (DictionaryProvider{}).Draw(context.Background(), WordRequest{Category: domain.Category{Name: "Empty"}}); !errors.Is(err, ErrNoWords) {
+		t.Errorf("empty category error = %v, want ErrNoWords", err)
+	}
+}
+
+// countingFetch returns a new pair for each call, named after its pool ("en/animals-3").
+func countingFetch(calls *atomic.Int32) datamuseFetch {
+	return func(_ context.Context, language string, theme *domain.InfiniteTheme) (domain.WordPair, error) {
+		n := calls.Add(1)
+		return domain.WordPair{Real: fmt.Sprintf("%s-%d", poolKey(language, theme), n), Trap: "Trap"}, nil
+	}
+}
+
+func waitForPool(t *testing.T, p *DatamusePool, language string, size int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for p.Len(language) < size {
+		if time.Now().After(deadline) {
+			t.Fatalf("pool %s has %d pairs, want %d", language, p.Len(language), size)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestDatamusePool(t *testing.T) {
+	var calls atomic.Int32
+	p := newDatamusePool(3, countingFetch(&calls))
+
+	// An empty pool doesn't wait for Datamuse, it starts filling up
+	if _, err := p.Draw(context.Background(), WordRequest{Language: "en"}); !errors.Is(err, ErrNoWords) {
+		t.Fatalf("Draw() on an empty pool error = %v, want ErrNoWords", err)
+	}
+	waitForPool(t, p, "en", 3)
+
+	pair, err := p.Draw(context.Background(), WordRequest{Language: "en-US"})
+	if err != nil || pair.Real == "" {
+		t.Fatalf("Draw() = %+v, %v", pair, err)
+	}
+	waitForPool(t, p, "en", 3) // Refilled after the draw
+
+	// Languages without a Datamuse vocabulary never fetch
+	before := calls.Load()
+	if _, err := p.Draw(context.Background(), WordRequest{Language: "fr"}); !errors.Is(err, ErrNoWords) {
+		t.Errorf("Draw(fr) error = %v, want ErrNoWords", err)
+	}
+	p.Prefetch("fr", "de")
+	time.Sleep(20 * time.Millisecond)
+	if calls.Load() != before {
+		t.Errorf("languages without Datamuse were fetched")
+	}
+}
+
+func TestDatamusePoolStopsOnErrors(t *testing.T) {
+	var calls atomic.Int32
+	p := newDatamusePool(5, func(_ context.Context, _ string, theme *domain.InfiniteTheme) (domain.WordPair, error) {
+		if theme == nil { // Each theme has its own pool, giving up on its own
+			calls.Add(1)
+		}
+		return domain.WordPair{}, errors.New("datamuse is down")
+	})
+
+	p.Prefetch("en")
+	deadline := time.Now().Add(2 * time.Second)
+	for calls.Load() < maxFillFailures && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if got := calls.Load(); got != maxFillFailures {
+		t.Errorf("Datamuse was called %d times, want %d before giving up", got, maxFillFailures)
+	}
+}
+
+func TestDatamusePoolPrefersDifficulty(t *testing.T) {
+	p := newDatamusePool(3, nil)
+	p.pools["en"] = []domain.WordPair{
+		{Real: "Frog", Trap: "Toad", Difficulty: domain.DifficultyHard},
+		{Real: "Dog", Trap: "Cat", Difficulty: domain.DifficultyMedium},
+	}
+	p.filling["en"] = true // No refill
+
+	if pair, _ := p.Draw(context.Background(), WordRequest{Language: "en", Difficulty: domain.DifficultyHard}); pair.Real != "Frog" {
+		t.Errorf("hard draw = %+v, want Frog", pair)
+	}
+	if pair, _ := p.Draw(context.Background(), WordRequest{Language: "en", Difficulty: domain.DifficultyHard}); pair.Real != "Dog" {
+		t.Errorf("draw without hard pairs = %+v, want Dog", pair)
+	}
+}
+
+// stubProvider always fails or always returns the same pair.
+type stubProvider struct {
+	pair domain.WordPair
+	err  error
+}
+
+func (s stubProvider) Draw(context.Context, WordRequest) (domain.WordPair, error) {
+	return s.pair, s.err
+}
+
+func TestFallbackProvidersCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p := FallbackProviders{stubProvider{pair: domain.WordPair{Real: "Comet", Trap: "Meteor"}}}
+	if _, err := p.Draw(ctx, WordRequest{Language: "en"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Draw() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestHubCategoryRefsHideUnservedInfinite(t *testing.T) {
+	offline := NewAssociationProvider(map[string]*AssociationGraph{"de": {}})
+	for name, tc := range map[string]struct {
+		provider WordProvider
+		language string
+		infinite bool
+	}{
+		"datamuse":          {newDatamusePool(1, nil), "es", true},
+		"no vocabulary":     {newDatamusePool(1, nil), "fr", false},
+		"offline":           {FallbackProviders{newDatamusePool(1, nil), offline}, "de", true},
+		"offline base":      {offline, "de-AT", true},
+		"no provider":       {nil, "en", false},
+		"any language stub": {stubProvider{}, "fr", true},
+	} {
+		h := NewHub()
+		h.SetInfiniteProvider(tc.provider)
+		refs := h.CategoryRefs(tc.language)
+		listed := slices.ContainsFunc(refs, func(ref CategoryRef) bool { return IsInfiniteCategory(ref.Name) })
+		if listed != tc.infinite {
+			t.Errorf("%s: Infinite listed = %v, want %v", name, listed, tc.infinite)
+		}
+		if len(refs) == 0 {
+			t.Errorf("%s: no category listed", name)
+		}
+	}
+}
+
+func TestInfiniteGameUsesProvider(t *testing.T) {
+	for name, tc := range map[string]struct {
+		provider WordProvider
+		infinite bool
+	}{
+		"pair ready":  {stubProvider{pair: domain.WordPair{Real: "Comet", Trap: "Meteor"}}, true},
+		"empty pool":  {stubProvider{err: ErrNoWords}, false},
+		"no provider": {nil, false},
+		"fallback":    {FallbackProviders{stubProvider{err: ErrNoWords}, stubProvider{pair: domain.WordPair{Real: "Comet", Trap: "Meteor"}}}, true},
+		"all empty":   {FallbackProviders{stubProvider{err: ErrNoWords}, stubProvider{err: ErrNoWords}}, false},
+		"none":        {FallbackProviders{}, false},
+	} {
+		h := NewHub()
+		h.SetInfiniteProvider(tc.provider)
+		l, _ := h.CreateLobby("infinite-"+name, nil)
+		l.Config.Language = "en"
+		for _, id := range []string{"p1", "p2", "p3"} {
+			l.AddPlayerSafe(&domain.Player{ID: id, Name: id})
+		}
+
+		if err := l.StartGame(context.Background(), infiniteCategory(), domain.ModeHard); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := l.pairCategory.ID == InfiniteCategoryID; got != tc.infinite {
+			t.Errorf("%s: drew %+v from %+v, want Infinite = %v", name, l.CurrentPair, l.pairCategory, tc.infinite)
+		}
+		if l.CurrentPair.Real == "" {
+			t.Errorf("%s: no pair drawn", name)
+		}
+	}
+}
@@ -0,0 +1,451 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"impostor/internal/domain"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CommunityCategory gathers the Infinite pairs players liked the most, per language.
+const (
+	CommunityCategory   = "👥 Community"
+	CommunityCategoryID = "community"
+)
+
+// RatingConfig decides what the votes of the players do.
+// A pair must have been rated in MinLobbies different lobbies before joining Community or being excluded,
+// so the players of a single lobby can't decide for everyone.
+type RatingConfig struct {
+	CommunityMinScore int           // Upvotes minus downvotes an Infinite pair needs to join the Community category
+	ExcludeDownvotes  int           // Downvotes after which a pair with more down than upvotes is no longer drawn (0 = never)
+	MinLobbies        int           // Lobbies that must have rated a pair before it counts (0 or 1 = any)
+	SaveInterval      time.Duration // How often Run saves the tallies changed by new votes
+}
+
+// DefaultRatingConfig returns the thresholds used when nothing is configured.
+func DefaultRatingConfig() RatingConfig {
+	return RatingConfig{CommunityMinScore: 3, ExcludeDownvotes: 5, MinLobbies: 3, SaveInterval: 10 * time.Second}
+}
+
+// RatedPair is the tally of the votes on one pair.
+type RatedPair struct {
+	Language string          `json:"language"`
+	Category string          `json:"category"` // Category ID, "infinite" for all the Infinite categories
+	Pair     domain.WordPair `json:"pair"`
+	Up       int             `json:"up"`
+	Down     int             `json:"down"`
+	Lobbies  []string        `json:"lobbies,omitempty"` // Lobbies the votes came from, up to MinLobbies of them
+}
+
+// Score is the number of upvotes minus the number of downvotes.
+func (r RatedPair) Score() int {
+	return r.Up - r.Down
+}
+
+// PairVote is a player's vote on the pair of a finished game, see Lobby.RatePair.
+type PairVote struct {
+	Lobby    string // ID of the lobby the vote comes from
+	Language string
+	Category string // Category ID the pair was drawn from
+	Pair     domain.WordPair
+	Rating   int // +1 or -1
+	Previous int // The player's previous vote on the same game, undone first; 0 if none
+}
+
+// RatingStore persists the tallies of PairRatings.
+type RatingStore interface {
+	Load() ([]RatedPair, error)
+	Save(pairs []RatedPair) error
+}
+
+// FileRatingStore keeps the tallies in a JSON file.
+type FileRatingStore struct {
+	path string
+}
+
+// NewFileRatingStore stores the tallies in path, created on the first vote.
+func NewFileRatingStore(path string) *FileRatingStore {
+	return &FileRatingStore{path: path}
+}
+
+func (s *FileRatingStore) Load() ([]RatedPair, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var pairs []RatedPair
+	if err := json.Unmarshal(data, &pairs); err != nil {
+		return nil, fmt.Errorf("%s: %w", s.path, err)
+	}
+	return pairs, nil
+}
+
+func (s *FileRatingStore) Save(pairs []RatedPair) error {
+	data, err := json.MarshalIndent(pairs, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write then rename, so a crash never leaves a half-written file.
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), "."+filepath.Base(s.path)+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// MemoryRatingStore keeps the tallies in memory only. Used when no ratings file is configured,
+// in which case the votes are lost on restart.
+type MemoryRatingStore struct {
+	mu    sync.Mutex
+	pairs []RatedPair
+}
+
+func NewMemoryRatingStore() *MemoryRatingStore {
+	return &MemoryRatingStore{}
+}
+
+func (s *MemoryRatingStore) Load() ([]RatedPair, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]RatedPair(nil), s.pairs...), nil
+}
+
+func (s *MemoryRatingStore) Save(pairs []RatedPair) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pairs = pairs
+	return nil
+}
+
+// PairRatings tallies the votes of the players on the pairs they played. Its methods are safe
+// on a nil *PairRatings, which records nothing, excludes nothing and has no Community pairs.
+// Votes are saved in batches by Run, and by Flush.
+type PairRatings struct {
+	config RatingConfig
+	store  RatingStore
+	saving sync.Mutex // Held during a save, so snapshots are written in order
+
+	mu    sync.Mutex
+	pairs map[string]*RatedPair // ratingKey -> tally
+	dirty bool                  // Votes not saved yet
+}
+
+// NewPairRatings loads the tallies of store.
+func NewPairRatings(config RatingConfig, store RatingStore) (*PairRatings, error) {
+	loaded, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	r := &PairRatings{config: config, store: store, pairs: make(map[string]*RatedPair, len(loaded))}
+	for _, p := range loaded {
+		r.pairs[ratingKey(p.Language, p.Category, p.Pair)] = &p
+	}
+	return r, nil
+}
+
+var currentRatings atomic.Pointer[PairRatings]
+
+// CurrentPairRatings returns the ratings in use, nil when votes are not recorded.
+func CurrentPairRatings() *PairRatings {
+	return currentRatings.Load()
+}
+
+// SetPairRatings replaces the ratings in use.
+func SetPairRatings(r *PairRatings) {
+	currentRatings.Store(r)
+}
+
+// Vote records a vote. It is saved with the next batch, see Run.
+func (r *PairRatings) Vote(v PairVote) {
+	if r == nil {
+		return
+	}
+	category := ratingCategory(v.Category)
+	key := ratingKey(v.Language, category, v.Pair)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tally, ok := r.pairs[key]
+	if !ok {
+		tally = &RatedPair{Language: v.Language, Category: category, Pair: v.Pair}
+		r.pairs[key] = tally
+	}
+	tally.add(v.Previous, -1)
+	tally.add(v.Rating, 1)
+	if v.Lobby != "" && len(tally.Lobbies) < max(r.config.MinLobbies, 1) && !slices.Contains(tally.Lobbies, v.Lobby) {
+		tally.Lobbies = append(tally.Lobbies, v.Lobby)
+	}
+	r.dirty = true
+}
+
+// Run saves the tallies every SaveInterval while votes come in, and a last time when ctx is done.
+// Failed saves are logged and retried with the next batch.
+func (r *PairRatings) Run(ctx context.Context) {
+	if r == nil {
+		return
+	}
+	interval := r.config.SaveInterval
+	if interval <= 0 {
+		interval = DefaultRatingConfig().SaveInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := r.Flush(); err != nil {
+				log.Printf("Could not save the pair ratings: %v", err)
+			}
+			return
+		case <-ticker.C:
+			if err := r.Flush(); err != nil {
+				log.Printf("Could not save the pair ratings: %v", err)
+			}
+		}
+	}
+}
+
+// Flush saves the tallies if votes came in since the last save.
+func (r *PairRatings) Flush() error {
+	if r == nil {
+		return nil
+	}
+	r.saving.Lock()
+	defer r.saving.Unlock()
+
+	r.mu.Lock()
+	if !r.dirty {
+		r.mu.Unlock()
+		return nil
+	}
+	pairs := r.snapshot()
+	r.dirty = false
+	r.mu.Unlock()
+
+	if err := r.store.Save(pairs); err != nil {
+		r.mu.Lock()
+		r.dirty = true
+		r.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (t *RatedPair) add(rating, n int) {
+	switch rating {
+	case 1:
+		t.Up += n
+	case -1:
+		t.Down += n
+	}
+}
+
+// snapshot returns a copy of the tallies in a stable order. Caller must hold the lock.
+func (r *PairRatings) snapshot() []RatedPair {
+	pairs := make([]RatedPair, 0, len(r.pairs))
+	for _, p := range r.pairs {
+		p := *p
+		p.Lobbies = slices.Clone(p.Lobbies)
+		pairs = append(pairs, p)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		a, b := pairs[i], pairs[j]
+		if a.Language != b.Language {
+			return a.Language < b.Language
+		}
+		if a.Category != b.Category {
+			return a.Category < b.Category
+		}
+		return pairID(a.Pair) < pairID(b.Pair)
+	})
+	return pairs
+}
+
+// Tally returns the votes on a pair of a category, drawn in language.
+func (r *PairRatings) Tally(language, categoryID string, pair domain.WordPair) RatedPair {
+	if r == nil {
+		return RatedPair{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t, ok := r.pairs[ratingKey(language, ratingCategory(categoryID), pair)]; ok {
+		tally := *t
+		tally.Lobbies = slices.Clone(t.Lobbies)
+		return tally
+	}
+	return RatedPair{}
+}
+
+// settled reports whether enough lobbies rated a pair for its votes to count.
+func (r *PairRatings) settled(t RatedPair) bool {
+	return len(t.Lobbies) >= r.config.MinLobbies
+}
+
+// Excluded reports whether a pair got so many downvotes that it should no longer be drawn.
+func (r *PairRatings) Excluded(language, categoryID string, pair domain.WordPair) bool {
+	if r == nil || r.config.ExcludeDownvotes <= 0 {
+		return false
+	}
+	t := r.Tally(language, categoryID, pair)
+	return t.Down >= r.config.ExcludeDownvotes && t.Down > t.Up && r.settled(t)
+}
+
+// Community returns the Infinite pairs of a language with a score of at least CommunityMinScore,
+// best first. Each gets an ID derived from its words, as dictionary pairs without one.
+func (r *PairRatings) Community(language string) []domain.WordPair {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	var rated []RatedPair
+	for _, p := range r.pairs {
+		if p.Language == language && p.Category == InfiniteCategoryID && p.Score() >= r.config.CommunityMinScore && p.Score() > 0 && r.settled(*p) {
+			rated = append(rated, *p)
+		}
+	}
+	r.mu.Unlock()
+
+	sort.Slice(rated, func(i, j int) bool {
+		if rated[i].Score() != rated[j].Score() {
+			return rated[i].Score() > rated[j].Score()
+		}
+		return rated[i].Pair.Real < rated[j].Pair.Real
+	})
+	pairs := make([]domain.WordPair, len(rated))
+	for i, p := range rated {
+		pairs[i] = p.Pair
+		pairs[i].ID = pairID(p.Pair)
+	}
+	return pairs
+}
+
+// filterExcluded drops the pairs excluded by their downvotes, unless that leaves none.
+// Only pairs of the dictionary are rated: custom categories, drawn without category ID, keep all their pairs.
+func filterExcluded(pairs []domain.WordPair, language, categoryID string) []domain.WordPair {
+	r := CurrentPairRatings()
+	if r == nil || categoryID == "" {
+		return pairs
+	}
+	kept := make([]domain.WordPair, 0, len(pairs))
+	for _, p := range pairs {
+		if !r.Excluded(language, categoryID, p) {
+			kept = append(kept, p)
+		}
+	}
+	if len(kept) == 0 {
+		return pairs
+	}
+	return kept
+}
+
+// maxExcludedRedraws bounds the draws of a provider that keeps dealing excluded pairs.
+const maxExcludedRedraws = 3
+
+// drawRated draws from an Infinite provider, skipping the pairs excluded by their downvotes.
+func drawRated(ctx context.Context, p WordProvider, req WordRequest) (domain.WordPair, error) {
+	for range maxExcludedRedraws {
+		pair, err := p.Draw(ctx, req)
+		if err != nil || !CurrentPairRatings().Excluded(req.Language, req.Category.ID, pair) {
+			return pair, err
+		}
+	}
+	return domain.WordPair{}, fmt.Errorf("%w: only downvoted pairs", ErrNoWords)
+}
+
+// communityCategory returns the Community category of a language, false while it has no pairs.
+func communityCategory(language string) (domain.Category, bool) {
+	pairs := CurrentPairRatings().Community(language)
+	if len(pairs) == 0 {
+		return domain.Category{}, false
+	}
+	return domain.Category{ID: CommunityCategoryID, Name: CommunityCategory, Pairs: pairs}, true
+}
+
+// ratingCategory is the category a pair is rated in: the Infinite categories share their votes,
+// and so does the Community category, made of Infinite pairs.
+func ratingCategory(categoryID string) string {
+	if categoryID == CommunityCategoryID || categoryID == InfiniteCategoryID || strings.HasPrefix(categoryID, InfiniteCategoryID+"-") {
+		return InfiniteCategoryID
+	}
+	return categoryID
+}
+
+func ratingKey(language, category string, pair domain.WordPair) string {
+	return language + "/" + category + "/" + pairID(pair)
+}
+
+// pairID identifies a pair: by its ID, or by its words for pairs without one (Infinite ones).
+func pairID(pair domain.WordPair) string {
+	if pair.ID != "" {
+		return pair.ID
+	}
+	return Slugify(pair.Real + " " + pair.Trap)
+}
+
+// ErrNothingToRate is returned by RatePair outside a finished game, for a pair of a custom category
+// or for a player who is not in the lobby.
+var ErrNothingToRate = errors.New("no pair to rate")
+
+// RatePair records a player's thumbs up (+1) or down (-1) on the pair of the finished game.
+// Voting again replaces the player's vote. The vote is acknowledged with a PAIR_RATED event.
+func (l *Lobby) RatePair(playerID string, rating int) error {
+	if rating != 1 && rating != -1 {
+		return fmt.Errorf("invalid rating %d", rating)
+	}
+
+	l.mu.Lock()
+	if _, ok := l.Players[playerID]; !ok || l.State != domain.StateFinished || l.pairCategory.ID == "" {
+		l.mu.Unlock()
+		return ErrNothingToRate
+	}
+	if l.pairVotes == nil {
+		l.pairVotes = make(map[string]int)
+	}
+	vote := PairVote{
+		Lobby:    l.ID,
+		Language: l.pairLanguage,
+		Category: l.pairCategory.ID,
+		Pair:     l.CurrentPair,
+		Rating:   rating,
+		Previous: l.pairVotes[playerID],
+	}
+	l.pairVotes[playerID] = rating
+	l.touch()
+	l.mu.Unlock()
+
+	CurrentPairRatings().Vote(vote)
+	label := "down"
+	if rating == 1 {
+		label = "up"
+	}
+	l.SendTo(playerID, map[string]interface{}{
+		"type":   "PAIR_RATED",
+		"rating": label,
+	})
+	return nil
+}
@@ -0,0 +1,251 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"impostor/internal/domain"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func usePairRatings(t *testing.T, config RatingConfig) *PairRatings {
+	t.Helper()
+	r, err := NewPairRatings(config, NewMemoryRatingStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetPairRatings(r)
+	t.Cleanup(func() { SetPairRatings(nil) })
+	return r
+}
+
+func TestPairRatingsPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratings.json")
+	r, err := NewPairRatings(DefaultRatingConfig(), NewFileRatingStore(path))
+	if err != nil {
+		t.Fatalf("missing file: %v", err)
+	}
+
+	comet := domain.WordPair{Real: "Comet", Trap: "Meteor"}
+	votes := []PairVote{
+		{Language: "en", Category: "infinite-space", Pair: comet, Rating: 1},
+		{Language: "en", Category: InfiniteCategoryID, Pair: comet, Rating: -1},
+		{Language: "en", Category: InfiniteCategoryID, Pair: comet, Rating: 1, Previous: -1}, // Changed their mind
+		{Language: "en", Category: "animals", Pair: domain.WordPair{ID: "dog-wolf", Real: "Dog", Trap: "Wolf"}, Rating: -1},
+	}
+	for _, v := range votes {
+		r.Vote(v)
+	}
+	// Votes are saved in batches
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("ratings saved before Flush: %v", err)
+	}
+	if err := r.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := NewPairRatings(DefaultRatingConfig(), NewFileRatingStore(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The Infinite themes and the Community category share the votes of ✨ Infinite
+	if got := reloaded.Tally("en", CommunityCategoryID, comet); got.Up != 2 || got.Down != 0 {
+		t.Errorf("Tally(comet) = %+v, want 2 up", got)
+	}
+	if got := reloaded.Tally("en", "animals", domain.WordPair{ID: "dog-wolf"}); got.Down != 1 {
+		t.Errorf("Tally(dog-wolf) = %+v, want 1 down", got)
+	}
+	if got := reloaded.Tally("es", InfiniteCategoryID, comet); got.Up != 0 {
+		t.Errorf("Tally in another language = %+v, want none", got)
+	}
+}
+
+func TestPairRatingsCommunityAndExclusion(t *testing.T) {
+	r := usePairRatings(t, RatingConfig{CommunityMinScore: 2, ExcludeDownvotes: 2})
+	liked := domain.WordPair{Real: "Comet", Trap: "Meteor"}
+	disliked := domain.WordPair{Real: "Cat", Trap: "Catalog"}
+	for _, rating := range []int{1, 1, -1, 1} {
+		r.Vote(PairVote{Language: "en", Category: InfiniteCategoryID, Pair: liked, Rating: rating})
+	}
+	for _, rating := range []int{-1, -1, 1} {
+		r.Vote(PairVote{Language: "en", Category: InfiniteCategoryID, Pair: disliked, Rating: rating})
+	}
+
+	cat, ok := GetCategoryByID(CommunityCategoryID, "en")
+	if !ok || len(cat.Pairs) != 1 || cat.Pairs[0].Real != "Comet" || cat.Pairs[0].ID != "comet-meteor" {
+		t.Fatalf("Community category = %+v, %v, want Comet only", cat, ok)
+	}
+	if got := GetCategoryByName(CommunityCategory, "en"); got.ID != CommunityCategoryID {
+		t.Errorf("GetCategoryByName(Community) = %q", got.ID)
+	}
+	if refs := GetAllCategoryRefs("en"); !containsRef(refs, CommunityCategoryID) {
+		t.Errorf("Community missing from %v", refs)
+	}
+	if refs := GetAllCategoryRefs("es"); containsRef(refs, CommunityCategoryID) {
+		t.Errorf("Community listed in Spanish without Spanish pairs: %v", refs)
+	}
+
+	if !r.Excluded("en", "infinite-animals", disliked) || r.Excluded("en", InfiniteCategoryID, liked) {
+		t.Error("only the disliked pair should be excluded")
+	}
+	for range 10 {
+		if _, err := drawRated(context.Background(), stubProvider{pair: disliked}, WordRequest{Language: "en", Category: infiniteCategory()}); !errors.Is(err, ErrNoWords) {
+			t.Fatalf("drawRated() of an excluded pair: err = %v, want ErrNoWords", err)
+		}
+	}
+
+	// Dictionary pairs are excluded too, unless nothing else is left
+	animals, _ := GetCategoryByID("animals", "en")
+	for range 3 {
+		r.Vote(PairVote{Language: "en", Category: "animals", Pair: animals.Pairs[0], Rating: -1})
+	}
+	animals.Pairs = animals.Pairs[:2]
+	for range 10 {
+		pair, _ := DictionaryProvider{}.Draw(context.Background(), WordRequest{Category: animals, Language: "en"})
+		if pair.ID == animals.Pairs[0].ID {
+			t.Fatalf("drew the excluded pair %q", pair.ID)
+		}
+	}
+	// A custom category, drawn without ID, ignores the votes on dictionary pairs
+	custom := domain.Category{Name: "Animals", Pairs: animals.Pairs}
+	drawn := make(map[string]bool)
+	for range 50 {
+		pair, _ := DictionaryProvider{}.Draw(context.Background(), WordRequest{Category: custom, Language: "en"})
+		drawn[pair.ID] = true
+	}
+	if !drawn[animals.Pairs[0].ID] {
+		t.Error("the custom category never dealt a pair downvoted in the dictionary")
+	}
+	animals.Pairs = animals.Pairs[:1]
+	if pair, err := (DictionaryProvider{}).Draw(context.Background(), WordRequest{Category: animals, Language: "en"}); err != nil || pair.ID != animals.Pairs[0].ID {
+		t.Errorf("Draw() with only excluded pairs = %+v, %v, want the pair anyway", pair, err)
+	}
+}
+
+func TestPairRatingsNeedSeveralLobbies(t *testing.T) {
+	r := usePairRatings(t, RatingConfig{CommunityMinScore: 2, ExcludeDownvotes: 2, MinLobbies: 2})
+	liked := domain.WordPair{Real: "Comet", Trap: "Meteor"}
+	disliked := domain.WordPair{Real: "Cat", Trap: "Catalog"}
+
+	// However many votes a single lobby casts, they don't count yet
+	for range 5 {
+		r.Vote(PairVote{Lobby: "lobby-1", Language: "en", Category: InfiniteCategoryID, Pair: liked, Rating: 1})
+		r.Vote(PairVote{Lobby: "lobby-1", Language: "en", Category: InfiniteCategoryID, Pair: disliked, Rating: -1})
+	}
+	if len(r.Community("en")) != 0 || r.Excluded("en", InfiniteCategoryID, disliked) {
+		t.Fatal("the votes of one lobby decided for everyone")
+	}
+
+	r.Vote(PairVote{Lobby: "lobby-2", Language: "en", Category: InfiniteCategoryID, Pair: liked, Rating: 1})
+	r.Vote(PairVote{Lobby: "lobby-2", Language: "en", Category: InfiniteCategoryID, Pair: disliked, Rating: -1})
+	if got := r.Community("en"); len(got) != 1 || got[0].Real != "Comet" {
+		t.Errorf("Community(en) = %+v, want Comet once a second lobby liked it", got)
+	}
+	if !r.Excluded("en", InfiniteCategoryID, disliked) {
+		t.Error("pair disliked by two lobbies is still drawn")
+	}
+	if got := r.Tally("en", InfiniteCategoryID, liked).Lobbies; len(got) != 2 {
+		t.Errorf("Lobbies = %v, want only the 2 needed", got)
+	}
+}
+
+func TestCommunityPairsAreScreened(t *testing.T) {
+	r := usePairRatings(t, RatingConfig{CommunityMinScore: 1})
+	r.Vote(PairVote{Language: "en", Category: InfiniteCategoryID, Pair: domain.WordPair{Real: "Comet", Trap: "Meteor"}, Rating: 1})
+
+	h := NewHub()
+	h.SetContentFilter(NewContentFilter(map[string][]string{"en": {"meteor"}}), domain.FilterOff)
+	l, _ := h.CreateLobby("screened", nil)
+	l.Config.Language = "en"
+	for _, id := range []string{"p1", "p2", "p3"} {
+		l.AddPlayerSafe(&domain.Player{ID: id, Name: id})
+	}
+	community, _ := GetCategoryByID(CommunityCategoryID, "en")
+	for range 10 {
+		l.State = domain.StateWaiting
+		if err := l.StartGame(context.Background(), community, domain.ModeHard); err != nil {
+			t.Fatal(err)
+		}
+		if l.CurrentPair.Trap == "Meteor" || l.pairCategory.ID == CommunityCategoryID {
+			t.Fatalf("dealt the blocked Community pair %+v", l.CurrentPair)
+		}
+	}
+}
+
+func containsRef(refs []CategoryRef, id string) bool {
+	for _, ref := range refs {
+		if ref.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestLobbyRatePair(t *testing.T) {
+	r := usePairRatings(t, RatingConfig{CommunityMinScore: 2})
+	h := NewHub()
+	h.SetInfiniteProvider(stubProvider{pair: domain.WordPair{Real: "Comet", Trap: "Meteor"}})
+	l, _ := h.CreateLobby("rated", nil)
+	l.Config.Language = "en"
+	client := &fakeClient{}
+	for _, id := range []string{"p1", "p2", "p3"} {
+		l.AddPlayerSafe(&domain.Player{ID: id, Name: id})
+	}
+	l.RegisterClient("p1", client)
+
+	if err := l.RatePair("p1", 1); !errors.Is(err, ErrNothingToRate) {
+		t.Errorf("RatePair() before any game: err = %v", err)
+	}
+	if err := l.StartGame(context.Background(), infiniteCategory(), domain.ModeHard); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.RatePair("p1", 1); !errors.Is(err, ErrNothingToRate) {
+		t.Errorf("RatePair() during the game: err = %v", err)
+	}
+	l.State = domain.StateFinished
+
+	for _, vote := range []struct {
+		player string
+		rating int
+	}{{"p1", 1}, {"p2", 1}, {"p2", -1}, {"p3", 1}} {
+		if err := l.RatePair(vote.player, vote.rating); err != nil {
+			t.Fatalf("RatePair(%s, %d) = %v", vote.player, vote.rating, err)
+		}
+	}
+	if err := l.RatePair("stranger", 1); !errors.Is(err, ErrNothingToRate) {
+		t.Errorf("RatePair() by a stranger: err = %v", err)
+	}
+	if err := l.RatePair("p1", 2); err == nil {
+		t.Error("RatePair(2) should fail")
+	}
+
+	if got := r.Tally("en", InfiniteCategoryID, l.CurrentPair); got.Up != 2 || got.Down != 1 {
+		t.Errorf("Tally = %+v, want 2 up and 1 down (p2 changed their vote)", got)
+	}
+	if len(r.Community("en")) != 0 {
+		t.Error("a pair with a score of 1 joined Community")
+	}
+	if ack, ok := client.sent[len(client.sent)-1].(map[string]interface{}); !ok || ack["type"] != "PAIR_RATED" || ack["rating"] != "up" {
+		t.Errorf("last message = %v, want PAIR_RATED up", client.sent[len(client.sent)-1])
+	}
+
+	// A new game starts a new round of votes
+	l.State = domain.StateWaiting
+	l.StartGame(context.Background(), infiniteCategory(), domain.ModeHard)
+	l.State = domain.StateFinished
+	l.RatePair("p2", 1)
+	if got := r.Community("en"); len(got) != 1 || got[0].Real != "Comet" {
+		t.Errorf("Community(en) = %+v, want Comet", got)
+	}
+
+	// Pairs of custom categories are not rated
+	custom := domain.Category{ID: "animals", Name: "Animals", Pairs: []domain.WordPair{{Real: "Dog", Trap: "Wolf"}}}
+	l.CustomCategories = map[string]domain.Category{custom.Name: custom}
+	l.State = domain.StateWaiting
+	l.StartGame(context.Background(), custom, domain.ModeHard)
+	l.State = domain.StateFinished
+	if err := l.RatePair("p1", -1); !errors.Is(err, ErrNothingToRate) {
+		t.Errorf("RatePair() of a custom pair: err = %v", err)
+	}
+}
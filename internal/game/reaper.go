@@ -0,0 +1,151 @@
+package game
+
+import (
+	"context"
+	"impostor/internal/domain"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// ReaperConfig defines how long lobbies may linger before the reaper removes them.
+// A zero TTL disables that rule.
+type ReaperConfig struct {
+	Interval    time.Duration // How often the reaper scans the Hub
+	UnjoinedTTL time.Duration // Lobbies created via the API that no player ever joined
+	FinishedTTL time.Duration // Lobbies sitting in FINISHED without a reset
+	IdleTTL     time.Duration // Lobbies with no activity at all
+}
+
+// DefaultReaperConfig returns conservative defaults suitable for production.
+func DefaultReaperConfig() ReaperConfig {
+	return ReaperConfig{
+		Interval:    time.Minute,
+		UnjoinedTTL: 10 * time.Minute,
+		FinishedTTL: 30 * time.Minute,
+		IdleTTL:     2 * time.Hour,
+	}
+}
+
+// ReaperStats is a snapshot of the reaper metrics.
+type ReaperStats struct {
+	ActiveLobbies int    `json:"active_lobbies"`
+	Runs          uint64 `json:"runs"`
+	Unjoined      uint64 `json:"reaped_unjoined"`
+	Finished      uint64 `json:"reaped_finished"`
+	Idle          uint64 `json:"reaped_idle"`
+}
+
+// reaperCounters holds the running totals. Updated atomically, so reads need no lock.
+type reaperCounters struct {
+	runs     atomic.Uint64
+	unjoined atomic.Uint64
+	finished atomic.Uint64
+	idle     atomic.Uint64
+}
+
+// ReaperStats returns the current reaper metrics.
+func (h *Hub) ReaperStats() ReaperStats {
+	h.mu.RLock()
+	active := len(h.lobbies)
+	h.mu.RUnlock()
+
+	return ReaperStats{
+		ActiveLobbies: active,
+		Runs:          h.reaped.runs.Load(),
+		Unjoined:      h.reaped.unjoined.Load(),
+		Finished:      h.reaped.finished.Load(),
+		Idle:          h.reaped.idle.Load(),
+	}
+}
+
+// StartReaper runs Reap periodically until ctx is cancelled.
+func (h *Hub) StartReaper(ctx context.Context, cfg ReaperConfig) {
+	if cfg.Interval <= 0 {
+		log.Println("Lobby reaper disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if n := h.Reap(cfg, now); n > 0 {
+					log.Printf("Reaper removed %d lobbies", n)
+				}
+			}
+		}
+	}()
+}
+
+// Reap removes every lobby that expired according to cfg, as of now.
+// Connected clients of a reaped lobby are notified and disconnected.
+// Returns the number of lobbies removed.
+func (h *Hub) Reap(cfg ReaperConfig, now time.Time) int {
+	h.reaped.runs.Add(1)
+
+	// Snapshot the lobbies first so we don't hold the Hub lock while inspecting each one.
+	candidates := h.ListLobbies()
+
+	type victim struct {
+		lobby  *Lobby
+		reason string
+	}
+	victims := make([]victim, 0)
+	for _, l := range candidates {
+		if reason := l.expiry(cfg, now); reason != "" {
+			victims = append(victims, victim{lobby: l, reason: reason})
+		}
+	}
+
+	removed := 0
+	for _, v := range victims {
+		h.mu.Lock()
+		// The lobby may have been deleted (or replaced) in the meantime.
+		if current, ok := h.lobbies[v.lobby.ID]; !ok || current != v.lobby {
+			h.mu.Unlock()
+			continue
+		}
+		delete(h.lobbies, v.lobby.ID)
+		h.mu.Unlock()
+		h.removed(v.lobby.ID)
+
+		switch v.reason {
+		case "unjoined":
+			h.reaped.unjoined.Add(1)
+		case "finished":
+			h.reaped.finished.Add(1)
+		case "idle":
+			h.reaped.idle.Add(1)
+		}
+
+		log.Printf("Reaping lobby %s (%s)", v.lobby.ID, v.reason)
+		v.lobby.Close("Lobby closed due to inactivity")
+		removed++
+	}
+
+	return removed
+}
+
+// expiry returns why the lobby should be reaped, or "" if it should be kept.
+func (l *Lobby) expiry(cfg ReaperConfig, now time.Time) string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if !l.joined && cfg.UnjoinedTTL > 0 && now.Sub(l.CreatedAt) >= cfg.UnjoinedTTL {
+		return "unjoined"
+	}
+	if l.State == domain.StateFinished && cfg.FinishedTTL > 0 && !l.FinishedAt.IsZero() &&
+		now.Sub(l.FinishedAt) >= cfg.FinishedTTL {
+		return "finished"
+	}
+	if cfg.IdleTTL > 0 && now.Sub(l.LastActivity) >= cfg.IdleTTL {
+		return "idle"
+	}
+	return ""
+}
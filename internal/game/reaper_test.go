@@ -0,0 +1,108 @@
+package game
+
+import (
+	"impostor/internal/domain"
+	"testing"
+	"time"
+)
+
+type fakeClient struct {
+	sent   []any
+	closed bool
+}
+
+func (f *fakeClient) WriteJSON(v any) error {
+	f.sent = append(f.sent, v)
+	return nil
+}
+
+func (f *fakeClient) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestReap(t *testing.T) {
+	cfg := ReaperConfig{
+		UnjoinedTTL: 10 * time.Minute,
+		FinishedTTL: 30 * time.Minute,
+		IdleTTL:     2 * time.Hour,
+	}
+
+	h := NewHub()
+	unjoined, _ := h.CreateLobby("unjoined", nil)
+
+	active, _ := h.CreateLobby("active", nil)
+	active.AddPlayerSafe(&domain.Player{ID: "p1", Name: "Ana"})
+
+	finished, _ := h.CreateLobby("finished", nil)
+	finished.AddPlayerSafe(&domain.Player{ID: "p2", Name: "Bob"})
+	client := &fakeClient{}
+	finished.RegisterClient("p2", client)
+	finished.State = domain.StateFinished
+	finished.FinishedAt = unjoined.CreatedAt
+
+	// Nothing has expired yet.
+	if n := h.Reap(cfg, unjoined.CreatedAt.Add(time.Minute)); n != 0 {
+		t.Fatalf("Reap() removed %d lobbies too early", n)
+	}
+
+	// Past the unjoined and finished TTLs, but not the idle one.
+	if n := h.Reap(cfg, unjoined.CreatedAt.Add(31*time.Minute)); n != 2 {
+		t.Fatalf("Reap() = %d, want 2", n)
+	}
+	if _, ok := h.GetLobby("unjoined"); ok {
+		t.Error("unjoined lobby was not reaped")
+	}
+	if _, ok := h.GetLobby("finished"); ok {
+		t.Error("finished lobby was not reaped")
+	}
+	if _, ok := h.GetLobby("active"); !ok {
+		t.Error("active lobby was reaped")
+	}
+	if !client.closed || len(client.sent) != 1 {
+		t.Errorf("client of reaped lobby was not notified and closed: %+v", client)
+	}
+
+	// Eventually the active lobby goes idle too.
+	if n := h.Reap(cfg, active.LastActivity.Add(3*time.Hour)); n != 1 {
+		t.Fatalf("Reap() = %d, want 1", n)
+	}
+
+	stats := h.ReaperStats()
+	if stats.ActiveLobbies != 0 || stats.Unjoined != 1 || stats.Finished != 1 || stats.Idle != 1 || stats.Runs != 3 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+// reentrantClient leaves the lobby when closed, as the disconnect handler of a real connection does.
+type reentrantClient struct {
+	fakeClient
+	lobby    *Lobby
+	playerID string
+}
+
+func (c *reentrantClient) Close() error {
+	c.lobby.RemovePlayer(c.playerID)
+	return c.fakeClient.Close()
+}
+
+func TestCloseReleasesLock(t *testing.T) {
+	l := NewLobby("closing", nil)
+	l.AddPlayerSafe(&domain.Player{ID: "p1", Name: "Ana"})
+	client := &reentrantClient{lobby: l, playerID: "p1"}
+	l.RegisterClient("p1", client)
+
+	done := make(chan struct{})
+	go func() {
+		l.Close("bye")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Close() deadlocked with a client leaving the lobby")
+	}
+	if !client.closed || l.HasPlayer("p1") {
+		t.Errorf("client closed = %v, player still in = %v", client.closed, l.HasPlayer("p1"))
+	}
+}
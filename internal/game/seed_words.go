@@ -0,0 +1,59 @@
+package game
+
+// Seed words start the Infinite category: a random seed is the real word and
+// a related word becomes the trap. They are registered per language in languages.go.
+
+var seedWordsEN = []string{
+	"fire", "water", "earth", "wind", "magic", "science", "space", "time",
+	"love", "war", "peace", "king", "queen", "apple", "banana", "car",
+	"house", "dog", "cat", "bird", "fish", "book", "computer", "phone",
+	"music", "art", "happy", "sad", "fast", "slow", "red", "blue",
+	"city", "forest", "mountain", "ocean", "river", "desert", "snow", "rain",
+	"adventure", "mystery", "history", "future", "robot", "alien", "ghost", "vampire",
+	"coffee", "tea", "beer", "wine", "pizza", "burger", "sushi", "cake",
+	"doctor", "teacher", "police", "thief", "judge", "lawyer", "actor", "singer",
+}
+
+var seedWordsES = []string{
+	"fuego", "agua", "tierra", "viento", "magia", "ciencia", "espacio", "tiempo",
+	"amor", "guerra", "paz", "rey", "reina", "manzana", "plátano", "coche",
+	"casa", "perro", "gato", "pájaro", "pez", "libro", "ordenador", "teléfono",
+	"música", "arte", "feliz", "triste", "rápido", "lento", "rojo", "azul",
+	"ciudad", "bosque", "montaña", "océano", "río", "desierto", "nieve", "lluvia",
+	"aventura", "misterio", "historia", "futuro", "robot", "alien", "fantasma", "vampiro",
+	"café", "té", "cerveza", "vino", "pizza", "hamburguesa", "sushi", "pastel",
+	"doctor", "profesor", "policía", "ladrón", "juez", "abogado", "actor", "cantante",
+}
+
+var seedWordsFR = []string{
+	"feu", "eau", "terre", "vent", "magie", "science", "espace", "temps",
+	"amour", "guerre", "paix", "roi", "reine", "pomme", "banane", "voiture",
+	"maison", "chien", "chat", "oiseau", "poisson", "livre", "ordinateur", "téléphone",
+	"musique", "art", "heureux", "triste", "rapide", "lent", "rouge", "bleu",
+	"ville", "forêt", "montagne", "océan", "rivière", "désert", "neige", "pluie",
+	"aventure", "mystère", "histoire", "avenir", "robot", "extraterrestre", "fantôme", "vampire",
+	"café", "thé", "bière", "vin", "pizza", "hamburger", "sushi", "gâteau",
+	"médecin", "professeur", "police", "voleur", "juge", "avocat", "acteur", "chanteur",
+}
+
+var seedWordsDE = []string{
+	"Feuer", "Wasser", "Erde", "Wind", "Magie", "Wissenschaft", "Weltraum", "Zeit",
+	"Liebe", "Krieg", "Frieden", "König", "Königin", "Apfel", "Banane", "Auto",
+	"Haus", "Hund", "Katze", "Vogel", "Fisch", "Buch", "Computer", "Telefon",
+	"Musik", "Kunst", "glücklich", "traurig", "schnell", "langsam", "rot", "blau",
+	"Stadt", "Wald", "Berg", "Ozean", "Fluss", "Wüste", "Schnee", "Regen",
+	"Abenteuer", "Rätsel", "Geschichte", "Zukunft", "Roboter", "Außerirdischer", "Geist", "Vampir",
+	"Kaffee", "Tee", "Bier", "Wein", "Pizza", "Burger", "Sushi", "Kuchen",
+	"Arzt", "Lehrer", "Polizei", "Dieb", "Richter", "Anwalt", "Schauspieler", "Sänger",
+}
+
+var seedWordsPT = []string{
+	"fogo", "água", "terra", "vento", "magia", "ciência", "espaço", "tempo",
+	"amor", "guerra", "paz", "rei", "rainha", "maçã", "banana", "carro",
+	"casa", "cão", "gato", "pássaro", "peixe", "livro", "computador", "telefone",
+	"música", "arte", "feliz", "triste", "rápido", "lento", "vermelho", "azul",
+	"cidade", "floresta", "montanha", "oceano", "rio", "deserto", "neve", "chuva",
+	"aventura", "mistério", "história", "futuro", "robô", "extraterrestre", "fantasma", "vampiro",
+	"café", "chá", "cerveja", "vinho", "pizza", "hambúrguer", "sushi", "bolo",
+	"médico", "professor", "polícia", "ladrão", "juiz", "advogado", "ator", "cantor",
+}
@@ -0,0 +1,44 @@
+package game
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+)
+
+// IssueToken gives a player of the lobby a new secret token, replacing any previous one.
+// Player IDs are public, every member sees them in PLAYER_LIST: the token is only sent to the player,
+// and proves who they are outside their websocket connection (REST uploads...).
+func (l *Lobby) IssueToken(playerID string) (string, error) {
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(secret)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.Players[playerID]; !ok {
+		return "", ErrPlayerNotInLobby
+	}
+	if l.tokens == nil {
+		l.tokens = make(map[string]string)
+	}
+	l.tokens[playerID] = token
+	return token, nil
+}
+
+// PlayerForToken returns the ID of the player holding the token, if they are still in the lobby.
+func (l *Lobby) PlayerForToken(token string) (string, bool) {
+	if token == "" {
+		return "", false
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for playerID, t := range l.tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			return playerID, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,34 @@
+package game
+
+import (
+	"impostor/internal/domain"
+	"testing"
+)
+
+func TestLobbyTokens(t *testing.T) {
+	l := NewLobby("lobby", nil)
+	l.AddPlayerSafe(&domain.Player{ID: "p1", Name: "Ana"})
+
+	if _, err := l.IssueToken("stranger"); err == nil {
+		t.Error("IssueToken() for a player not in the lobby should fail")
+	}
+	first, _ := l.IssueToken("p1")
+	token, err := l.IssueToken("p1")
+	if err != nil || token == "" || token == first {
+		t.Fatalf("IssueToken() = %q, %v, want a new token", token, err)
+	}
+
+	if id, ok := l.PlayerForToken(token); !ok || id != "p1" {
+		t.Errorf("PlayerForToken() = %q, %v, want p1", id, ok)
+	}
+	for _, wrong := range []string{"", first, "p1"} {
+		if _, ok := l.PlayerForToken(wrong); ok {
+			t.Errorf("PlayerForToken(%q) should fail", wrong)
+		}
+	}
+
+	l.RemovePlayer("p1")
+	if _, ok := l.PlayerForToken(token); ok {
+		t.Error("the token outlived the player")
+	}
+}
@@ -0,0 +1,57 @@
+package game
+
+import "impostor/internal/domain"
+
+// TranslatePair returns the translation of a pair of the given category into language,
+// matching categories and pairs by ID. ok is false when there is no translation
+// (pairs from Datamuse or custom categories, or a dictionary without it), in which case
+// the pair is returned unchanged. A translation without hint or description keeps the ones of pair.
+func TranslatePair(categoryID string, pair domain.WordPair, language string) (domain.WordPair, bool) {
+	if categoryID == "" || pair.ID == "" {
+		return pair, false
+	}
+	cat, ok := GetCategoryByID(categoryID, language)
+	if !ok {
+		return pair, false
+	}
+	for _, p := range cat.Pairs {
+		if p.ID == pair.ID {
+			if p.Hint == "" {
+				p.Hint = pair.Hint
+			}
+			if p.Description == "" {
+				p.Description = pair.Description
+			}
+			return p, true
+		}
+	}
+	return pair, false
+}
+
+// playerLanguage is the language a player sees the game in: their own, or else the lobby's.
+func (l *Lobby) playerLanguage(p *domain.Player) string {
+	if p.Language != "" {
+		return p.Language
+	}
+	return l.Config.Language
+}
+
+// translatePair translates a pair drawn for this lobby's current game from the language it was drawn in.
+// Caller must hold the lock.
+func (l *Lobby) translatePair(pair domain.WordPair, language string) domain.WordPair {
+	if language == l.pairLanguage {
+		return pair
+	}
+	translated, _ := TranslatePair(l.pairCategory.ID, pair, language)
+	return translated
+}
+
+// categoryName is the name of the category of the current game in language. Caller must hold the lock.
+func (l *Lobby) categoryName(language string) string {
+	if l.pairCategory.ID != "" {
+		if cat, ok := GetCategoryByID(l.pairCategory.ID, language); ok {
+			return cat.Name
+		}
+	}
+	return l.pairCategory.Name
+}
@@ -0,0 +1,165 @@
+package game
+
+import (
+	"context"
+	"impostor/internal/domain"
+	"testing"
+)
+
+func TestTranslatePair(t *testing.T) {
+	dog := domain.WordPair{ID: "dog-wolf", Real: "Dog", Trap: "Wolf"}
+
+	got, ok := TranslatePair("animals", dog, "es")
+	if !ok || got.Real != "Perro" || got.Trap != "Lobo" {
+		t.Errorf("TranslatePair(animals, dog-wolf, es) = %+v, %v, want Perro/Lobo", got, ok)
+	}
+
+	// Regional variants use the base language
+	if got, _ := TranslatePair("animals", dog, "es-MX"); got.Real != "Perro" {
+		t.Errorf("TranslatePair(animals, dog-wolf, es-MX) = %q, want Perro", got.Real)
+	}
+
+	for name, tc := range map[string]struct {
+		category string
+		pair     domain.WordPair
+	}{
+		"unknown category": {"nope", dog},
+		"unknown pair":     {"animals", domain.WordPair{ID: "nope", Real: "Dog", Trap: "Wolf"}},
+		"datamuse pair":    {InfiniteCategoryID, domain.WordPair{Real: "Dog", Trap: "Wolf"}},
+	} {
+		got, ok := TranslatePair(tc.category, tc.pair, "es")
+		if ok || got.Real != tc.pair.Real || got.Trap != tc.pair.Trap {
+			t.Errorf("%s: TranslatePair = %+v, %v, want the pair unchanged", name, got, ok)
+		}
+	}
+}
+
+func TestTranslatePairKeepsHints(t *testing.T) {
+	original := CurrentDictionary()
+	defer SetDictionary(original)
+
+	// A dictionary whose Italian words have no hint or description yet
+	dir := t.TempDir()
+	writeFile(t, dir, "it.json", `{"categories": [{"id": "animals", "name": "Animali", "pairs": [{"id": "dog-wolf", "real": "Cane", "trap": "Lupo"}]}]}`)
+	d, err := LoadDictionaryDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetDictionary(d)
+
+	animals, _ := GetCategoryByID("animals", "en")
+	dog := animals.Pairs[0]
+	got, ok := TranslatePair("animals", dog, "it")
+	if !ok || got.Real != "Cane" || got.Hint != dog.Hint || got.Description != dog.Description {
+		t.Errorf("TranslatePair(animals, dog-wolf, it) = %+v, %v, want Cane with the English hint and description", got, ok)
+	}
+	if got, _ := TranslatePair("animals", dog, "fr"); got.Hint == dog.Hint || got.Hint == "" {
+		t.Errorf("TranslatePair(animals, dog-wolf, fr) hint = %q, want the French one", got.Hint)
+	}
+}
+
+func TestStartGameTranslatesCards(t *testing.T) {
+	h := NewHub()
+	l, _ := h.CreateLobby("mixed", nil)
+	l.Config.Language = "en"
+
+	clients := make(map[string]*fakeClient)
+	for _, p := range []*domain.Player{
+		{ID: "p1", Name: "Ann"},
+		{ID: "p2", Name: "Ana", Language: "es"},
+		{ID: "p3", Name: "Anne", Language: "fr"},
+	} {
+		l.AddPlayerSafe(p)
+		clients[p.ID] = &fakeClient{}
+		l.RegisterClient(p.ID, clients[p.ID])
+	}
+
+	animals, _ := GetCategoryByID("animals", "en")
+	animals.Pairs = animals.Pairs[:1] // Dog / Wolf
+	if err := l.StartGame(context.Background(), animals, domain.ModeEasy); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][2]string{ // real, trap
+		"p1": {"Dog", "Wolf"},
+		"p2": {"Perro", "Lobo"},
+		"p3": {"Chien", "Loup"},
+	}
+	for id, client := range clients {
+		var start map[string]interface{}
+		for _, sent := range client.sent {
+			if msg, ok := sent.(map[string]interface{}); ok && msg["status"] == "PLAYING" {
+				start = msg
+			}
+		}
+		if start == nil {
+			t.Fatalf("%s got no PLAYING message", id)
+		}
+		word := want[id][0]
+		if start["role"] == string(domain.RoleImpostor) {
+			word = want[id][1]
+		}
+		if start["displayed_word"] != word {
+			t.Errorf("%s (%s) was shown %v, want %s", id, start["role"], start["displayed_word"], word)
+		}
+	}
+}
+
+func TestTranslateFromDrawLanguage(t *testing.T) {
+	r := usePairRatings(t, RatingConfig{})
+	h := NewHub()
+	l, _ := h.CreateLobby("switched", nil)
+	l.Config.Language = "en"
+	en, es := &fakeClient{}, &fakeClient{}
+	l.AddPlayerSafe(&domain.Player{ID: "p1", Name: "Ann", Language: "en"})
+	l.AddPlayerSafe(&domain.Player{ID: "p2", Name: "Ana", Language: "es"})
+	l.AddPlayerSafe(&domain.Player{ID: "p3", Name: "Bob", Language: "en"})
+	l.RegisterClient("p1", en)
+	l.RegisterClient("p2", es)
+
+	animals, _ := GetCategoryByID("animals", "en")
+	animals.Pairs = animals.Pairs[:1] // Dog / Wolf
+	if err := l.StartGame(context.Background(), animals, domain.ModeHard); err != nil {
+		t.Fatal(err)
+	}
+
+	// The lobby language changes before the game ends, the pair is still English
+	l.Config.Language = "es"
+	impostor := "p1"
+	for id, p := range l.Players {
+		if p.Role == domain.RoleImpostor {
+			impostor = id
+		}
+	}
+	l.finishGame(impostor)
+
+	for _, tc := range []struct {
+		client     *fakeClient
+		real, trap string
+	}{{en, "Dog", "Wolf"}, {es, "Perro", "Lobo"}} {
+		msg := tc.client.sent[len(tc.client.sent)-1].(map[string]interface{})
+		if msg["real"] != tc.real || msg["trap"] != tc.trap {
+			t.Errorf("FINISHED = %v, want %s/%s", msg, tc.real, tc.trap)
+		}
+	}
+
+	if err := l.RatePair("p2", 1); err != nil {
+		t.Fatal(err)
+	}
+	if got := r.Tally("en", "animals", l.CurrentPair); got.Up != 1 {
+		t.Errorf("Tally(en) = %+v, want the vote filed under the draw language", got)
+	}
+}
+
+func TestImpostorTextInPlayerLanguage(t *testing.T) {
+	h := NewHub()
+	l, _ := h.CreateLobby("mixed-hard", nil)
+	l.Config.Language = "en"
+	l.Config.Mode = domain.ModeHard
+	l.AddPlayerSafe(&domain.Player{ID: "p1", Name: "Ana", Language: "es", Role: domain.RoleImpostor})
+
+	card := l.GetCardForPlayer("p1", domain.WordPair{Real: "Dog", Trap: "Wolf"})
+	if card.DisplayedWord != "ERES EL IMPOSTOR" {
+		t.Errorf("impostor card = %q, want the Spanish text", card.DisplayedWord)
+	}
+}
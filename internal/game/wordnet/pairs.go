@@ -0,0 +1,180 @@
+package wordnet
+
+import (
+	"impostor/internal/domain"
+	"impostor/internal/game"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Tag marks the imported pairs, so reviewers can find them in the dictionary.
+const Tag = "wordnet"
+
+// Options selects the categories and pairs built by Categories.
+type Options struct {
+	// Hypernyms restricts the import to these categories, by word ("bird", or "ave" in Spanish)
+	// or offset ("01503061"). Empty imports every synset with enough hyponyms, which is a lot.
+	Hypernyms   []string
+	MinSiblings int  // Hyponyms a category needs, after filtering, to be kept (at least 2)
+	MaxPairs    int  // Pairs per category, 0 for no limit
+	Phrases     bool // Keep words of several words ("ice cream"), skipped by default
+}
+
+// Categories builds one candidate category per hypernym, whose pairs are two of its hyponyms:
+// sibling kinds of the same thing, such as two kinds of bird. Each hyponym is the real word
+// of one pair and the trap of another, with its gloss as description.
+//
+// IDs are derived from the English words whatever the lexicon's language, so categories and pairs
+// imported in several languages are linked as translations. Categories are sorted by name; when
+// two hypernyms share a name or ID, the one with the most pairs is kept.
+func (l *Lexicon) Categories(opts Options) []domain.Category {
+	if opts.MinSiblings < 2 {
+		opts.MinSiblings = 2
+	}
+
+	var categories []domain.Category
+	for _, hypernym := range l.hypernyms(opts.Hypernyms) {
+		if cat, ok := l.category(hypernym, opts); ok {
+			categories = append(categories, cat)
+		}
+	}
+
+	// Most pairs first, so they win the name and ID clashes
+	sort.SliceStable(categories, func(i, j int) bool {
+		if len(categories[i].Pairs) != len(categories[j].Pairs) {
+			return len(categories[i].Pairs) > len(categories[j].Pairs)
+		}
+		return categories[i].ID < categories[j].ID
+	})
+	names, ids := make(map[string]bool), make(map[string]bool)
+	kept := categories[:0]
+	for _, cat := range categories {
+		if names[cat.Name] || ids[cat.ID] {
+			continue
+		}
+		names[cat.Name], ids[cat.ID] = true, true
+		kept = append(kept, cat)
+	}
+	sort.Slice(kept, func(i, j int) bool { return kept[i].Name < kept[j].Name })
+	return kept
+}
+
+// hypernyms returns the synsets matching the requested words or offsets, all of them if none.
+func (l *Lexicon) hypernyms(refs []string) []*Synset {
+	var synsets []*Synset
+	if len(refs) == 0 {
+		for _, s := range l.synsets {
+			synsets = append(synsets, s)
+		}
+	} else {
+		wanted := make(map[string]bool, len(refs))
+		for _, ref := range refs {
+			wanted[strings.ToLower(strings.TrimSpace(ref))] = true
+		}
+		for offset, s := range l.synsets {
+			if wanted[offset] || wanted[strings.ToLower(l.keys[offset])] || len(s.Words) > 0 && wanted[strings.ToLower(s.Words[0])] {
+				synsets = append(synsets, s)
+			}
+		}
+	}
+	sort.Slice(synsets, func(i, j int) bool { return synsets[i].Offset < synsets[j].Offset })
+	return synsets
+}
+
+// sibling is a hyponym kept for the pairs.
+type sibling struct {
+	word, key, gloss string
+}
+
+func (l *Lexicon) category(hypernym *Synset, opts Options) (domain.Category, bool) {
+	if len(hypernym.Words) == 0 {
+		return domain.Category{}, false // Not translated
+	}
+	name := hypernym.Words[0]
+
+	var siblings []sibling
+	seen := map[string]bool{strings.ToLower(name): true}
+	for _, offset := range hypernym.Hyponyms {
+		s, ok := l.synsets[offset]
+		if !ok || len(s.Words) == 0 {
+			continue
+		}
+		word := s.Words[0]
+		if !usable(word, opts.Phrases) || seen[strings.ToLower(word)] || related(word, name) {
+			continue
+		}
+		seen[strings.ToLower(word)] = true
+		siblings = append(siblings, sibling{word: word, key: l.keys[offset], gloss: s.Gloss})
+	}
+	if len(siblings) < opts.MinSiblings {
+		return domain.Category{}, false
+	}
+	sort.Slice(siblings, func(i, j int) bool { return siblings[i].key < siblings[j].key })
+
+	// Each sibling is paired with the next one, around the circle: a/b, b/c, c/a
+	cat := domain.Category{ID: game.Slugify(l.keys[hypernym.Offset]), Name: capitalize(name)}
+	for i, real := range siblings {
+		trap := siblings[(i+1)%len(siblings)]
+		if len(siblings) == 2 && i == 1 {
+			break // b/a would repeat a/b
+		}
+		if related(real.word, trap.word) {
+			continue
+		}
+		cat.Pairs = append(cat.Pairs, domain.WordPair{
+			ID:          game.Slugify(real.key + " " + trap.key),
+			Real:        capitalize(real.word),
+			Trap:        capitalize(trap.word),
+			Description: sentence(real.gloss),
+			Tags:        []string{Tag},
+		})
+		if opts.MaxPairs > 0 && len(cat.Pairs) == opts.MaxPairs {
+			break
+		}
+	}
+	if len(cat.Pairs) == 0 {
+		return domain.Category{}, false
+	}
+	return cat, true
+}
+
+// usable rejects proper nouns, abbreviations and, unless phrases is set, words of several words.
+func usable(word string, phrases bool) bool {
+	if utf8.RuneCountInString(word) < 3 {
+		return false
+	}
+	if !phrases && strings.ContainsAny(word, " -") {
+		return false
+	}
+	for _, r := range word {
+		if unicode.IsUpper(r) || unicode.IsDigit(r) || r == '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// related reports whether a word contains the other ("oak" and "oak tree"), a giveaway rather than a trap.
+func related(a, b string) bool {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	return strings.Contains(a, b) || strings.Contains(b, a)
+}
+
+func capitalize(word string) string {
+	r, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToUpper(r)) + word[size:]
+}
+
+// sentence turns a gloss into a description: capitalized, with a final period.
+func sentence(gloss string) string {
+	if gloss == "" {
+		return ""
+	}
+	gloss = capitalize(gloss)
+	if !strings.HasSuffix(gloss, ".") {
+		gloss += "."
+	}
+	return gloss
+}
@@ -0,0 +1,166 @@
+// Package wordnet reads WordNet lexicons to suggest word pairs for the dictionaries (see cmd/wnimport).
+//
+// The structure comes from the noun data file of Princeton WordNet 3.0 (data.noun, WNdb format).
+// Other languages take their words from an Open Multilingual Wordnet file (wn-data-spa.tab...),
+// whose synsets are the offsets of WordNet 3.0.
+package wordnet
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Synset is a set of synonyms with the same meaning.
+type Synset struct {
+	Offset   string   // Byte offset in data.noun, the synset ID shared by the Open Multilingual Wordnet
+	Words    []string // Most common first, spaces instead of underscores
+	Gloss    string   // Definition, without the usage examples
+	Hyponyms []string // Offsets of the more specific synsets (dog -> puppy, hound...)
+}
+
+// Lexicon holds the noun synsets of a language.
+type Lexicon struct {
+	synsets map[string]*Synset
+	keys    map[string]string // Offset -> English word, for IDs that link translations
+}
+
+// Synset returns the synset at offset.
+func (l *Lexicon) Synset(offset string) (*Synset, bool) {
+	s, ok := l.synsets[offset]
+	return s, ok
+}
+
+// Len returns the number of synsets.
+func (l *Lexicon) Len() int {
+	return len(l.synsets)
+}
+
+// ReadData parses a WordNet noun data file.
+func ReadData(r io.Reader) (*Lexicon, error) {
+	l := &Lexicon{synsets: make(map[string]*Synset), keys: make(map[string]string)}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, " ") { // License header
+			continue
+		}
+		s, err := parseSynset(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		l.synsets[s.Offset] = s
+		l.keys[s.Offset] = s.Words[0]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// parseSynset parses "offset lex_filenum ss_type w_cnt word lex_id... p_cnt ptr... | gloss".
+func parseSynset(line string) (*Synset, error) {
+	data, gloss, _ := strings.Cut(line, " | ")
+	fields := strings.Fields(data)
+	if len(fields) < 4 {
+		return nil, fmt.Errorf("truncated synset %q", line)
+	}
+	s := &Synset{Offset: fields[0], Gloss: cleanGloss(gloss)}
+
+	wordCount, err := strconv.ParseInt(fields[3], 16, 0)
+	if err != nil || wordCount < 1 {
+		return nil, fmt.Errorf("%s: invalid word count %q", s.Offset, fields[3])
+	}
+	i := 4
+	for range wordCount {
+		if i+1 >= len(fields) {
+			return nil, fmt.Errorf("%s: missing words", s.Offset)
+		}
+		s.Words = append(s.Words, cleanWord(fields[i]))
+		i += 2 // Skip lex_id
+	}
+
+	pointerCount, err := strconv.Atoi(fields[i])
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid pointer count %q", s.Offset, fields[i])
+	}
+	i++
+	for range pointerCount {
+		if i+3 >= len(fields) {
+			return nil, fmt.Errorf("%s: missing pointers", s.Offset)
+		}
+		symbol, offset, pos, sourceTarget := fields[i], fields[i+1], fields[i+2], fields[i+3]
+		// Only the semantic hyponyms: instances (~i) are proper nouns
+		if symbol == "~" && pos == "n" && sourceTarget == "0000" {
+			s.Hyponyms = append(s.Hyponyms, offset)
+		}
+		i += 4
+	}
+	return s, nil
+}
+
+// cleanWord turns "ice_cream" into "ice cream" and drops the syntactic markers of adjectives ("(a)").
+func cleanWord(word string) string {
+	if i := strings.IndexByte(word, '('); i > 0 {
+		word = word[:i]
+	}
+	return strings.ReplaceAll(word, "_", " ")
+}
+
+// cleanGloss keeps the definition of a gloss, dropping its quoted usage examples.
+func cleanGloss(gloss string) string {
+	gloss = strings.TrimSpace(gloss)
+	if i := strings.Index(gloss, `; "`); i >= 0 {
+		gloss = gloss[:i]
+	}
+	return strings.TrimSpace(strings.TrimPrefix(gloss, `"`))
+}
+
+// ReadOMW parses an Open Multilingual Wordnet tab file ("00001740-n<TAB>spa:lemma<TAB>entidad")
+// and returns a copy of l with the words of that language. Synsets without a translation have no words;
+// definitions ("spa:def") replace the English glosses, which are dropped otherwise.
+// Only nouns are read, the other parts of speech are not in l.
+func (l *Lexicon) ReadOMW(r io.Reader) (*Lexicon, error) {
+	translated := &Lexicon{synsets: make(map[string]*Synset, len(l.synsets)), keys: l.keys}
+	for offset, s := range l.synsets {
+		translated.synsets[offset] = &Synset{Offset: offset, Hyponyms: s.Hyponyms}
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("line %d: expected synset, type and value", n)
+		}
+		offset, ok := strings.CutSuffix(fields[0], "-n")
+		if !ok {
+			continue
+		}
+		s, ok := translated.synsets[offset]
+		if !ok {
+			continue
+		}
+		_, kind, _ := strings.Cut(fields[1], ":")
+		value := strings.TrimSpace(fields[len(fields)-1]) // Definitions have an index column before the text
+		switch kind {
+		case "lemma":
+			s.Words = append(s.Words, cleanWord(value))
+		case "def":
+			if s.Gloss == "" {
+				s.Gloss = value
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return translated, nil
+}
@@ -0,0 +1,122 @@
+package wordnet
+
+import (
+	"strings"
+	"testing"
+)
+
+// A few synsets in the format of data.noun: bird and its kinds, one of them with an instance.
+const data = `  1 This software and database is being provided to you, the LICENSEE, by
+  2 Princeton University under the following license.
+01503061 05 n 01 bird 0 007 @ 01471682 n 0000 ~ 01525720 n 0000 ~ 01578821 n 0000 ~ 01604330 n 0000 ~ 01795088 n 0000 ~ 01529999 n 0000 ~i 09999999 n 0000 | warm-blooded egg-laying vertebrates; "birds are everywhere"
+01525720 05 n 02 passerine 0 passeriform_bird 0 001 @ 01503061 n 0000 | perching birds
+01578821 05 n 01 parrot 0 001 @ 01503061 n 0000 | usually brightly colored tropical birds
+01604330 05 n 02 bird_of_prey 0 raptor 0 001 @ 01503061 n 0000 | any of numerous carnivorous birds
+01795088 05 n 01 game_fowl 0 001 @ 01503061 n 0000 | heavy-bodied largely ground-feeding domestic or game birds
+01529999 05 n 01 songbird 0 001 @ 01503061 n 0000 | any bird having a musical call
+09999999 18 n 01 Tweety 0 001 @i 01503061 n 0000 | a cartoon canary
+01471682 05 n 01 vertebrate 0 001 ~ 01503061 n 0000 | animals having a bony skeleton
+`
+
+func readData(t *testing.T) *Lexicon {
+	t.Helper()
+	l, err := ReadData(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestReadData(t *testing.T) {
+	l := readData(t)
+	if l.Len() != 8 {
+		t.Errorf("Len() = %d, want 8", l.Len())
+	}
+	bird, ok := l.Synset("01503061")
+	if !ok {
+		t.Fatal("bird synset missing")
+	}
+	if bird.Gloss != "warm-blooded egg-laying vertebrates" || len(bird.Hyponyms) != 5 {
+		t.Errorf("bird = %+v, want the gloss without example and 5 hyponyms (no instance)", bird)
+	}
+	if raptor, _ := l.Synset("01604330"); strings.Join(raptor.Words, ",") != "bird of prey,raptor" {
+		t.Errorf("raptor words = %q", raptor.Words)
+	}
+
+	for _, bad := range []string{
+		"01503061 05 n zz bird 0 000 | bad word count",
+		"01503061 05 n 02 bird 0 000 | missing word",
+		"01503061 05 n 01 bird 0 002 @ 01471682 n 0000 | missing pointer",
+	} {
+		if _, err := ReadData(strings.NewReader(bad)); err == nil {
+			t.Errorf("ReadData(%q) should fail", bad)
+		}
+	}
+}
+
+func TestCategories(t *testing.T) {
+	l := readData(t)
+
+	categories := l.Categories(Options{Hypernyms: []string{"bird"}})
+	if len(categories) != 1 {
+		t.Fatalf("Categories() = %+v, want bird only", categories)
+	}
+	cat := categories[0]
+	if cat.ID != "bird" || cat.Name != "Bird" {
+		t.Errorf("category = %s/%s", cat.ID, cat.Name)
+	}
+	// Phrases, words containing "bird" and the instance are skipped; two siblings make a single pair
+	if len(cat.Pairs) != 1 || cat.Pairs[0].Real != "Parrot" || cat.Pairs[0].Trap != "Passerine" {
+		t.Errorf("pairs = %+v, want Parrot/Passerine", cat.Pairs)
+	}
+	if p := cat.Pairs[0]; p.ID != "parrot-passerine" || p.Description != "Usually brightly colored tropical birds." || p.Tags[0] != Tag {
+		t.Errorf("pair = %+v", p)
+	}
+
+	phrases := l.Categories(Options{Hypernyms: []string{"01503061"}, Phrases: true, MinSiblings: 3, MaxPairs: 2})
+	if len(phrases) != 1 || len(phrases[0].Pairs) != 2 || phrases[0].Pairs[0].Real != "Game fowl" {
+		t.Errorf("with phrases = %+v, want 2 pairs from Game fowl", phrases)
+	}
+	if got := l.Categories(Options{Hypernyms: []string{"bird"}, MinSiblings: 3}); len(got) != 0 {
+		t.Errorf("MinSiblings 3 kept %+v", got)
+	}
+	// Vertebrate has a single hyponym
+	if got := l.Categories(Options{}); len(got) != 1 || got[0].ID != "bird" {
+		t.Errorf("Categories() of everything = %+v, want bird", got)
+	}
+}
+
+func TestReadOMW(t *testing.T) {
+	omw := "# Spanish\tspa\thttp://example.org\tCC BY\n" +
+		"01503061-n\tspa:lemma\tave\n" +
+		"01503061-n\tspa:lemma\tpájaro\n" +
+		"01525720-n\tspa:lemma\tpaseriforme\n" +
+		"01578821-n\tspa:lemma\tloro\n" +
+		"01578821-n\tspa:def\t0\tave tropical de colores vivos\n" +
+		"01795088-n\tspa:lemma\tgallinácea\n" +
+		"00001740-v\tspa:lemma\trespirar\n"
+	l, err := readData(t).ReadOMW(strings.NewReader(omw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	categories := l.Categories(Options{Hypernyms: []string{"ave"}, MinSiblings: 3})
+	if len(categories) != 1 {
+		t.Fatalf("Categories() = %+v, want ave", categories)
+	}
+	cat := categories[0]
+	if cat.ID != "bird" || cat.Name != "Ave" || len(cat.Pairs) != 3 {
+		t.Fatalf("category = %+v, want the English ID and 3 pairs", cat)
+	}
+	// Sorted by English word, so the pairs line up with an English import
+	if p := cat.Pairs[1]; p.ID != "parrot-passerine" || p.Real != "Loro" || p.Trap != "Paseriforme" || p.Description != "Ave tropical de colores vivos." {
+		t.Errorf("pair = %+v", p)
+	}
+	if p := cat.Pairs[0]; p.Description != "" {
+		t.Errorf("English gloss kept: %+v", p)
+	}
+
+	if _, err := readData(t).ReadOMW(strings.NewReader("01503061-n\tspa:lemma\n")); err == nil {
+		t.Error("ReadOMW() of a truncated line should fail")
+	}
+}
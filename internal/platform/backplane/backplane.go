@@ -0,0 +1,35 @@
+// Package backplane connects the replicas of the server so that players
+// connected to different processes can share a lobby.
+//
+// Every lobby is owned by exactly one replica, which holds its state in its Hub.
+// Other replicas forward their players' commands to the owner and relay the
+// owner's events back to their websocket connections, all through a Backplane.
+package backplane
+
+import (
+	"context"
+	"time"
+)
+
+// Backplane is the pub/sub and ownership primitive shared by all replicas.
+type Backplane interface {
+	// Publish delivers payload to every current subscriber of channel.
+	Publish(ctx context.Context, channel string, payload []byte) error
+
+	// Subscribe calls handler for every message published on channel, in order,
+	// until the returned cancel function is called.
+	Subscribe(ctx context.Context, channel string, handler func(payload []byte)) (cancel func(), err error)
+
+	// Claim tries to take ownership of key for ttl. If owner already holds it, the lease is refreshed.
+	// Returns the owner after the attempt, which is someone else if the key was taken.
+	Claim(ctx context.Context, key, owner string, ttl time.Duration) (string, error)
+
+	// Owner returns the current owner of key, or "" if it is unclaimed.
+	Owner(ctx context.Context, key string) (string, error)
+
+	// Release gives up ownership of key, if owner still holds it.
+	Release(ctx context.Context, key, owner string) error
+
+	// Close releases the resources held by the backplane.
+	Close() error
+}
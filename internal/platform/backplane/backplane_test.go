@@ -0,0 +1,102 @@
+package backplane
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// testBackplane runs the behaviour every Backplane implementation must provide.
+func testBackplane(t *testing.T, bp Backplane) {
+	ctx := context.Background()
+
+	t.Run("pubsub", func(t *testing.T) {
+		received := make(chan string, 10)
+		cancel, err := bp.Subscribe(ctx, "lobby.1.in", func(payload []byte) {
+			received <- string(payload)
+		})
+		if err != nil {
+			t.Fatalf("Subscribe() error = %v", err)
+		}
+
+		for _, msg := range []string{"one", "two"} {
+			if err := bp.Publish(ctx, "lobby.1.in", []byte(msg)); err != nil {
+				t.Fatalf("Publish() error = %v", err)
+			}
+		}
+		// Other channels must not leak into the subscription.
+		bp.Publish(ctx, "lobby.2.in", []byte("other"))
+
+		for _, want := range []string{"one", "two"} {
+			select {
+			case got := <-received:
+				if got != want {
+					t.Errorf("received %q, want %q", got, want)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatalf("timed out waiting for %q", want)
+			}
+		}
+
+		cancel()
+		bp.Publish(ctx, "lobby.1.in", []byte("after cancel"))
+		select {
+		case got := <-received:
+			t.Errorf("received %q after cancel", got)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("ownership", func(t *testing.T) {
+		owner, err := bp.Claim(ctx, "lobby.1", "replica-a", time.Minute)
+		if err != nil || owner != "replica-a" {
+			t.Fatalf("Claim() = %q, %v; want replica-a", owner, err)
+		}
+		if owner, _ := bp.Claim(ctx, "lobby.1", "replica-b", time.Minute); owner != "replica-a" {
+			t.Errorf("second Claim() = %q, want replica-a", owner)
+		}
+		if owner, _ := bp.Claim(ctx, "lobby.1", "replica-a", time.Minute); owner != "replica-a" {
+			t.Errorf("refresh Claim() = %q, want replica-a", owner)
+		}
+
+		// Only the owner may release.
+		bp.Release(ctx, "lobby.1", "replica-b")
+		if owner, _ := bp.Owner(ctx, "lobby.1"); owner != "replica-a" {
+			t.Errorf("Owner() = %q after foreign release, want replica-a", owner)
+		}
+		bp.Release(ctx, "lobby.1", "replica-a")
+		if owner, _ := bp.Owner(ctx, "lobby.1"); owner != "" {
+			t.Errorf("Owner() = %q after release, want empty", owner)
+		}
+	})
+}
+
+func TestMemory(t *testing.T) {
+	testBackplane(t, NewMemory())
+}
+
+func TestRedis(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	bp, err := NewRedis(context.Background(), mr.Addr(), "impostor:")
+	if err != nil {
+		t.Fatalf("NewRedis() error = %v", err)
+	}
+	defer bp.Close()
+
+	testBackplane(t, bp)
+
+	// A lease that expired and was taken over is not released by its former owner
+	ctx := context.Background()
+	bp.Claim(ctx, "lobby.2", "replica-a", time.Second)
+	mr.FastForward(2 * time.Second)
+	if owner, _ := bp.Claim(ctx, "lobby.2", "replica-b", time.Minute); owner != "replica-b" {
+		t.Fatalf("Claim() of an expired lease = %q, want replica-b", owner)
+	}
+	bp.Release(ctx, "lobby.2", "replica-a")
+	if owner, _ := bp.Owner(ctx, "lobby.2"); owner != "replica-b" {
+		t.Errorf("Owner() = %q after a stale release, want replica-b", owner)
+	}
+}
@@ -0,0 +1,131 @@
+package backplane
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory is an in-process Backplane, used when the server runs as a single replica.
+type Memory struct {
+	mu     sync.Mutex
+	subs   map[string]map[int]*memorySub
+	nextID int
+	owners map[string]lease
+}
+
+type lease struct {
+	owner   string
+	expires time.Time
+}
+
+// memorySub delivers messages on its own goroutine so publishers never block on handlers.
+type memorySub struct {
+	queue chan []byte
+	done  chan struct{}
+}
+
+// NewMemory creates an empty in-process backplane.
+func NewMemory() *Memory {
+	return &Memory{
+		subs:   make(map[string]map[int]*memorySub),
+		owners: make(map[string]lease),
+	}
+}
+
+func (m *Memory) Publish(ctx context.Context, channel string, payload []byte) error {
+	m.mu.Lock()
+	subs := make([]*memorySub, 0, len(m.subs[channel]))
+	for _, sub := range m.subs[channel] {
+		subs = append(subs, sub)
+	}
+	m.mu.Unlock()
+
+	for _, sub := range subs {
+		select {
+		case sub.queue <- payload:
+		case <-sub.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (m *Memory) Subscribe(ctx context.Context, channel string, handler func(payload []byte)) (func(), error) {
+	sub := &memorySub{
+		queue: make(chan []byte, 64),
+		done:  make(chan struct{}),
+	}
+
+	m.mu.Lock()
+	id := m.nextID
+	m.nextID++
+	if m.subs[channel] == nil {
+		m.subs[channel] = make(map[int]*memorySub)
+	}
+	m.subs[channel][id] = sub
+	m.mu.Unlock()
+
+	go func() {
+		for {
+			select {
+			case payload := <-sub.queue:
+				handler(payload)
+			case <-sub.done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			m.mu.Lock()
+			delete(m.subs[channel], id)
+			if len(m.subs[channel]) == 0 {
+				delete(m.subs, channel)
+			}
+			m.mu.Unlock()
+			close(sub.done)
+		})
+	}
+	return cancel, nil
+}
+
+func (m *Memory) Claim(ctx context.Context, key, owner string, ttl time.Duration) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if current, ok := m.owners[key]; ok && current.owner != owner && now.Before(current.expires) {
+		return current.owner, nil
+	}
+	m.owners[key] = lease{owner: owner, expires: now.Add(ttl)}
+	return owner, nil
+}
+
+func (m *Memory) Owner(ctx context.Context, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, ok := m.owners[key]
+	if !ok || time.Now().After(current.expires) {
+		return "", nil
+	}
+	return current.owner, nil
+}
+
+func (m *Memory) Release(ctx context.Context, key, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if current, ok := m.owners[key]; ok && current.owner == owner {
+		delete(m.owners, key)
+	}
+	return nil
+}
+
+func (m *Memory) Close() error {
+	return nil
+}
@@ -0,0 +1,120 @@
+package backplane
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis is a Backplane built on Redis PUBLISH/SUBSCRIBE and SET NX leases.
+// Any server speaking the Redis protocol (Redis, Valkey, KeyDB, ...) works.
+type Redis struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedis connects to the Redis server at addr. Every key and channel is namespaced with prefix.
+func NewRedis(ctx context.Context, addr, prefix string) (*Redis, error) {
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("connecting to redis at %s: %w", addr, err)
+	}
+	return &Redis{client: client, prefix: prefix}, nil
+}
+
+func (r *Redis) Publish(ctx context.Context, channel string, payload []byte) error {
+	return r.client.Publish(ctx, r.prefix+channel, payload).Err()
+}
+
+func (r *Redis) Subscribe(ctx context.Context, channel string, handler func(payload []byte)) (func(), error) {
+	// The subscription outlives ctx, which only bounds the initial handshake.
+	ps := r.client.Subscribe(context.Background(), r.prefix+channel)
+	// Wait for the confirmation so no message published after Subscribe returns is lost.
+	if _, err := ps.Receive(ctx); err != nil {
+		ps.Close()
+		return nil, err
+	}
+
+	go func() {
+		for msg := range ps.Channel() {
+			handler([]byte(msg.Payload))
+		}
+	}()
+
+	return func() {
+		if err := ps.Close(); err != nil {
+			log.Printf("Error closing subscription to %s: %v", channel, err)
+		}
+	}, nil
+}
+
+// refreshScript extends the lease of KEYS[1] by ARGV[2] milliseconds if ARGV[1] still holds it.
+var refreshScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+// releaseScript deletes KEYS[1] if ARGV[1] still holds it, so an expired lease taken over
+// by another replica in the meantime is left alone.
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+func (r *Redis) Claim(ctx context.Context, key, owner string, ttl time.Duration) (string, error) {
+	key = r.prefix + key
+
+	for {
+		ok, err := r.client.SetNX(ctx, key, owner, ttl).Result()
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return owner, nil
+		}
+
+		// We may already own it: refresh the lease, in one step so we never extend someone else's.
+		refreshed, err := refreshScript.Run(ctx, r.client, []string{key}, owner, ttl.Milliseconds()).Int()
+		if err != nil {
+			return "", err
+		}
+		if refreshed == 1 {
+			return owner, nil
+		}
+
+		current, err := r.client.Get(ctx, key).Result()
+		if errors.Is(err, redis.Nil) {
+			continue // The lease expired in the meantime, try again
+		}
+		if err != nil {
+			return "", err
+		}
+		if current == owner {
+			continue // Released and claimed again by the same owner, refresh once more
+		}
+		return current, nil
+	}
+}
+
+func (r *Redis) Owner(ctx context.Context, key string) (string, error) {
+	owner, err := r.client.Get(ctx, r.prefix+key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return owner, err
+}
+
+func (r *Redis) Release(ctx context.Context, key, owner string) error {
+	return releaseScript.Run(ctx, r.client, []string{r.prefix + key}, owner).Err()
+}
+
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,234 @@
+package server
+
+import (
+	"impostor/internal/domain"
+	"impostor/internal/game"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Config holds the runtime settings of the server.
+// Every field can be overridden through an environment variable.
+type Config struct {
+	Reaper    game.ReaperConfig
+	Limits    game.Limits
+	RateLimit RateLimitConfig
+
+	// DictionaryDir holds one JSON/YAML dictionary file per language, reloaded on change.
+	// When empty, only the built-in dictionary is used.
+	DictionaryDir            string
+	DictionaryReloadInterval time.Duration
+
+	// ProxyHeader holds the client IP when the server runs behind a load balancer (X-Forwarded-For...).
+	// It is only read from requests of the TrustedProxies (IPs or CIDR ranges); without any, from every request.
+	// When empty, rate limits apply to the connecting IP, which is the load balancer's.
+	ProxyHeader    string
+	TrustedProxies []string
+
+	// AdminToken protects the /api/admin endpoints. Admin routes are disabled when empty.
+	AdminToken string
+
+	// ReplicaID identifies this process on the backplane. Random by default.
+	ReplicaID string
+	// RedisAddr selects the Redis backplane (host:port). When empty, the in-process backplane is used
+	// and the server can only run as a single replica.
+	RedisAddr   string
+	RedisPrefix string
+
+	// DatamuseEnabled, DatamuseURL and DatamuseTimeout configure the API behind the ✨ Infinite category.
+	// DatamusePoolSize is the number of Infinite pairs fetched ahead of time per language.
+	// After DatamuseBreakerThreshold failures in a row, the API is skipped for DatamuseBreakerCooldown.
+	DatamuseEnabled          bool
+	DatamuseURL              string
+	DatamuseTimeout          time.Duration
+	DatamusePoolSize         int
+	DatamuseBreakerThreshold int
+	DatamuseBreakerCooldown  time.Duration
+	// DatamuseFilter decides which related words can become traps and hints; its blocklist is read
+	// from the DatamuseBlocklist file, one word per line.
+	DatamuseFilter    game.DatamuseFilterConfig
+	DatamuseBlocklist string
+
+	// AssociationsDir holds one word-association file per language (en.tsv...), used for Infinite
+	// pairs when Datamuse is disabled or has none ready. Empty disables offline Infinite.
+	AssociationsDir string
+
+	// Ratings decides when the pairs rated by the players join 👥 Community or stop being drawn.
+	// The votes are saved to RatingsFile in batches, or kept in memory when empty. Each replica keeps its own.
+	Ratings     game.RatingConfig
+	RatingsFile string
+
+	// ContentFilter is the mode of new lobbies for names and chat (off, mask or reject); leaders can change it.
+	// ContentFilterDir holds one blocklist per language (en.txt...). Without it nothing is blocked.
+	ContentFilter    domain.ContentFilter
+	ContentFilterDir string
+}
+
+// DefaultConfig returns the settings used when nothing is configured.
+func DefaultConfig() Config {
+	return Config{
+		Reaper:      game.DefaultReaperConfig(),
+		Limits:      game.DefaultLimits(),
+		RateLimit:   DefaultRateLimitConfig(),
+		ReplicaID:   uuid.New().String(),
+		RedisPrefix: "impostor:",
+
+		DatamuseEnabled:  true,
+		DatamuseURL:      game.DefaultDatamuseURL,
+		DatamuseTimeout:  game.DefaultDatamuseTimeout,
+		DatamusePoolSize: game.DefaultDatamusePoolSize,
+
+		DatamuseBreakerThreshold: game.DefaultBreakerThreshold,
+		DatamuseBreakerCooldown:  game.DefaultBreakerCooldown,
+		DatamuseFilter:           game.DefaultDatamuseFilterConfig(),
+
+		Ratings:       game.DefaultRatingConfig(),
+		ContentFilter: domain.FilterOff,
+
+		DictionaryReloadInterval: 10 * time.Second,
+	}
+}
+
+// LoadConfig reads the configuration from the environment, falling back to DefaultConfig.
+func LoadConfig() Config {
+	cfg := DefaultConfig()
+
+	cfg.Reaper.Interval = envDuration("LOBBY_REAP_INTERVAL", cfg.Reaper.Interval)
+	cfg.Reaper.UnjoinedTTL = envDuration("LOBBY_UNJOINED_TTL", cfg.Reaper.UnjoinedTTL)
+	cfg.Reaper.FinishedTTL = envDuration("LOBBY_FINISHED_TTL", cfg.Reaper.FinishedTTL)
+	cfg.Reaper.IdleTTL = envDuration("LOBBY_IDLE_TTL", cfg.Reaper.IdleTTL)
+
+	cfg.Limits.MaxLobbies = envInt("MAX_LOBBIES", cfg.Limits.MaxLobbies)
+	cfg.Limits.DefaultMaxPlayers = envInt("LOBBY_MAX_PLAYERS", cfg.Limits.DefaultMaxPlayers)
+	cfg.Limits.MaxPlayersCeiling = envInt("LOBBY_MAX_PLAYERS_CEILING", cfg.Limits.MaxPlayersCeiling)
+
+	cfg.RateLimit.LobbyCreatesPerMinute = envInt("RATE_LOBBY_CREATES_PER_MIN", cfg.RateLimit.LobbyCreatesPerMinute)
+	cfg.RateLimit.JoinsPerMinute = envInt("RATE_JOINS_PER_MIN", cfg.RateLimit.JoinsPerMinute)
+	cfg.RateLimit.ChatPerSecond = envFloat("RATE_CHAT_PER_SEC", cfg.RateLimit.ChatPerSecond)
+	cfg.RateLimit.ChatBurst = envInt("RATE_CHAT_BURST", cfg.RateLimit.ChatBurst)
+	cfg.RateLimit.GamePerSecond = envFloat("RATE_GAME_PER_SEC", cfg.RateLimit.GamePerSecond)
+	cfg.RateLimit.GameBurst = envInt("RATE_GAME_BURST", cfg.RateLimit.GameBurst)
+	cfg.RateLimit.MaxViolations = envInt("RATE_MAX_VIOLATIONS", cfg.RateLimit.MaxViolations)
+
+	cfg.RateLimit.ViolationDecay = envDuration("RATE_VIOLATION_DECAY", cfg.RateLimit.ViolationDecay)
+	cfg.ProxyHeader = envString("PROXY_HEADER", cfg.ProxyHeader)
+	cfg.TrustedProxies = envList("TRUSTED_PROXIES", cfg.TrustedProxies)
+
+	cfg.DictionaryDir = envString("DICTIONARY_DIR", cfg.DictionaryDir)
+	cfg.DictionaryReloadInterval = envDuration("DICTIONARY_RELOAD_INTERVAL", cfg.DictionaryReloadInterval)
+
+	cfg.AdminToken = os.Getenv("ADMIN_TOKEN")
+
+	cfg.ReplicaID = envString("REPLICA_ID", cfg.ReplicaID)
+	cfg.RedisAddr = envString("REDIS_ADDR", cfg.RedisAddr)
+	cfg.RedisPrefix = envString("REDIS_PREFIX", cfg.RedisPrefix)
+
+	cfg.DatamuseEnabled = envBool("DATAMUSE_ENABLED", cfg.DatamuseEnabled)
+	cfg.DatamuseURL = envString("DATAMUSE_URL", cfg.DatamuseURL)
+	cfg.DatamuseTimeout = envDuration("DATAMUSE_TIMEOUT", cfg.DatamuseTimeout)
+	cfg.DatamusePoolSize = envInt("DATAMUSE_POOL_SIZE", cfg.DatamusePoolSize)
+	cfg.DatamuseBreakerThreshold = envInt("DATAMUSE_BREAKER_THRESHOLD", cfg.DatamuseBreakerThreshold)
+	cfg.DatamuseBreakerCooldown = envDuration("DATAMUSE_BREAKER_COOLDOWN", cfg.DatamuseBreakerCooldown)
+	cfg.DatamuseFilter.MinScore = envInt("DATAMUSE_MIN_SCORE", cfg.DatamuseFilter.MinScore)
+	cfg.DatamuseFilter.MaxWords = envInt("DATAMUSE_MAX_WORDS", cfg.DatamuseFilter.MaxWords)
+	cfg.DatamuseFilter.MinFrequency = envFloat("DATAMUSE_MIN_FREQUENCY", cfg.DatamuseFilter.MinFrequency)
+	cfg.DatamuseFilter.RejectSameStem = envBool("DATAMUSE_REJECT_SAME_STEM", cfg.DatamuseFilter.RejectSameStem)
+	cfg.DatamuseBlocklist = envString("DATAMUSE_BLOCKLIST", cfg.DatamuseBlocklist)
+	cfg.AssociationsDir = envString("ASSOCIATIONS_DIR", cfg.AssociationsDir)
+
+	cfg.RatingsFile = envString("RATINGS_FILE", cfg.RatingsFile)
+	cfg.Ratings.CommunityMinScore = envInt("COMMUNITY_MIN_SCORE", cfg.Ratings.CommunityMinScore)
+	cfg.Ratings.ExcludeDownvotes = envInt("RATING_EXCLUDE_DOWNVOTES", cfg.Ratings.ExcludeDownvotes)
+	cfg.Ratings.MinLobbies = envInt("RATING_MIN_LOBBIES", cfg.Ratings.MinLobbies)
+	cfg.Ratings.SaveInterval = envDuration("RATINGS_SAVE_INTERVAL", cfg.Ratings.SaveInterval)
+
+	if v := os.Getenv("CONTENT_FILTER"); v != "" {
+		if mode, err := game.ParseContentFilter(v); err != nil {
+			log.Printf("Invalid CONTENT_FILTER=%q, using default %s", v, cfg.ContentFilter)
+		} else {
+			cfg.ContentFilter = mode
+		}
+	}
+	cfg.ContentFilterDir = envString("CONTENT_FILTER_DIR", cfg.ContentFilterDir)
+
+	return cfg
+}
+
+func envString(key, def string) string {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		return v
+	}
+	return def
+}
+
+// envList reads a comma-separated list, ignoring blanks.
+func envList(key string, def []string) []string {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def
+	}
+	var list []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func envInt(key string, def int) int {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Printf("Invalid %s=%q, using default %d", key, v, def)
+		return def
+	}
+	return n
+}
+
+func envBool(key string, def bool) bool {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		log.Printf("Invalid %s=%q, using default %t", key, v, def)
+		return def
+	}
+	return b
+}
+
+func envFloat(key string, def float64) float64 {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		log.Printf("Invalid %s=%q, using default %g", key, v, def)
+		return def
+	}
+	return f
+}
+
+func envDuration(key string, def time.Duration) time.Duration {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("Invalid %s=%q, using default %s", key, v, def)
+		return def
+	}
+	return d
+}
@@ -0,0 +1,26 @@
+package server
+
+import (
+	"impostor/internal/domain"
+	"impostor/internal/game"
+	"log"
+)
+
+// loadContentFilter reads the blocklists of CONTENT_FILTER_DIR. Without them the lobbies block nothing,
+// whatever their content filter mode.
+func (s *Server) loadContentFilter() *game.ContentFilter {
+	dir := s.Config.ContentFilterDir
+	if dir == "" {
+		if s.Config.ContentFilter != domain.FilterOff {
+			log.Printf("CONTENT_FILTER is %s but no CONTENT_FILTER_DIR is set, nothing will be blocked", s.Config.ContentFilter)
+		}
+		return nil
+	}
+	f, err := game.LoadContentFilterDir(dir)
+	if err != nil {
+		log.Printf("Error loading the content filter blocklists from %s, nothing will be blocked: %v", dir, err)
+		return nil
+	}
+	log.Printf("Content filter blocklists loaded from %s for %v", dir, f.Languages())
+	return f
+}
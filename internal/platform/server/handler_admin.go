@@ -0,0 +1,90 @@
+package server
+
+import (
+	"crypto/subtle"
+	"impostor/internal/game"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupAdminRoutes registers the operator endpoints.
+// They are only available when an admin token is configured.
+func (s *Server) setupAdminRoutes() {
+	if s.Config.AdminToken == "" {
+		return
+	}
+
+	admin := s.App.Group("/api/admin", s.requireAdmin)
+	admin.Get("/lobbies", s.adminListLobbiesHandler)
+	admin.Get("/lobbies/:id", s.adminGetLobbyHandler)
+	admin.Delete("/lobbies/:id", s.adminCloseLobbyHandler)
+	admin.Post("/lobbies/:id/kick", s.adminKickPlayerHandler)
+
+	s.setupDictionaryAdminRoutes(admin)
+}
+
+// requireAdmin checks the "Authorization: Bearer <token>" header against the configured token.
+func (s *Server) requireAdmin(c *fiber.Ctx) error {
+	token := strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.Config.AdminToken)) != 1 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Unauthorized",
+		})
+	}
+	return c.Next()
+}
+
+func (s *Server) adminListLobbiesHandler(c *fiber.Ctx) error {
+	lobbies := s.Hub.ListLobbies()
+	summaries := make([]game.LobbySummary, 0, len(lobbies))
+	for _, l := range lobbies {
+		summaries = append(summaries, l.Summary())
+	}
+	return c.JSON(fiber.Map{
+		"lobbies": summaries,
+	})
+}
+
+func (s *Server) adminGetLobbyHandler(c *fiber.Ctx) error {
+	lobby, ok := s.Hub.GetLobby(c.Params("id"))
+	if !ok {
+		return s.lobbyNotFound(c, c.Params("id"))
+	}
+	return c.JSON(lobby.Details(c.QueryBool("secrets")))
+}
+
+func (s *Server) adminCloseLobbyHandler(c *fiber.Ctx) error {
+	reason := c.Query("reason", "Lobby closed by an administrator")
+	if !s.Hub.CloseLobby(c.Params("id"), reason) {
+		return s.lobbyNotFound(c, c.Params("id"))
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (s *Server) adminKickPlayerHandler(c *fiber.Ctx) error {
+	type KickPayload struct {
+		PlayerID string `json:"player_id"`
+		Reason   string `json:"reason"`
+	}
+	var payload KickPayload
+	if err := c.BodyParser(&payload); err != nil || payload.PlayerID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "player_id is required",
+		})
+	}
+	if payload.Reason == "" {
+		payload.Reason = "Kicked by an administrator"
+	}
+
+	lobby, ok := s.Hub.GetLobby(c.Params("id"))
+	if !ok {
+		return s.lobbyNotFound(c, c.Params("id"))
+	}
+	if !lobby.KickPlayer(payload.PlayerID, payload.Reason) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Player not found",
+		})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"impostor/internal/domain"
+	"impostor/internal/game"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newAdminTestServer() *Server {
+	cfg := DefaultConfig()
+	cfg.AdminToken = "secret"
+	return NewServerWithConfig(cfg)
+}
+
+func TestAdminRequiresToken(t *testing.T) {
+	s := newAdminTestServer()
+
+	resp, err := s.App.Test(httptest.NewRequest("GET", "/api/admin/lobbies", nil))
+	if err != nil {
+		t.Fatalf("App.Test error: %v", err)
+	}
+	if resp.StatusCode != 401 {
+		t.Errorf("Expected status 401 without token, got %d", resp.StatusCode)
+	}
+
+	req := httptest.NewRequest("GET", "/api/admin/lobbies", nil)
+	req.Header.Set("Authorization", "Bearer wrong")
+	resp, _ = s.App.Test(req)
+	if resp.StatusCode != 401 {
+		t.Errorf("Expected status 401 with wrong token, got %d", resp.StatusCode)
+	}
+}
+
+func TestAdminLobbyLifecycle(t *testing.T) {
+	s := newAdminTestServer()
+	lobby, _ := s.Hub.CreateLobby("lobby-1", nil)
+	lobby.AddPlayerSafe(&domain.Player{ID: "p1", Name: "Ana", Role: domain.RoleImpostor})
+
+	// List
+	req := httptest.NewRequest("GET", "/api/admin/lobbies", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, _ := s.App.Test(req)
+	if resp.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	var list struct {
+		Lobbies []game.LobbySummary `json:"lobbies"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(list.Lobbies) != 1 || list.Lobbies[0].PlayerCount != 1 {
+		t.Errorf("Unexpected lobby list: %+v", list.Lobbies)
+	}
+
+	// Details hide roles unless secrets are requested
+	for _, tc := range []struct {
+		url      string
+		wantRole domain.Role
+	}{
+		{"/api/admin/lobbies/lobby-1", ""},
+		{"/api/admin/lobbies/lobby-1?secrets=true", domain.RoleImpostor},
+	} {
+		req = httptest.NewRequest("GET", tc.url, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		resp, _ = s.App.Test(req)
+		var details game.LobbyDetails
+		if err := json.NewDecoder(resp.Body).Decode(&details); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if len(details.Players) != 1 || details.Players[0].Role != tc.wantRole {
+			t.Errorf("%s: unexpected players %+v", tc.url, details.Players)
+		}
+	}
+
+	// Kick, for good
+	lobby.RegisterClient("p1", &recordingClient{})
+	for _, want := range []int{204, 404} {
+		req = httptest.NewRequest("POST", "/api/admin/lobbies/lobby-1/kick", strings.NewReader(`{"player_id": "p1"}`))
+		req.Header.Set("Authorization", "Bearer secret")
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ = s.App.Test(req)
+		if resp.StatusCode != want {
+			t.Errorf("Kick: expected status %d, got %d", want, resp.StatusCode)
+		}
+	}
+	lobby.RemovePlayer("p1")
+	if _, err := lobby.AddPlayerSafe(&domain.Player{ID: "p1", Name: "Ana"}); !errors.Is(err, game.ErrPlayerKicked) {
+		t.Errorf("Rejoin after kick: err = %v, want ErrPlayerKicked", err)
+	}
+
+	// Force close
+	req = httptest.NewRequest("DELETE", "/api/admin/lobbies/lobby-1", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, _ = s.App.Test(req)
+	if resp.StatusCode != 204 {
+		t.Errorf("Expected status 204, got %d", resp.StatusCode)
+	}
+	if _, ok := s.Hub.GetLobby("lobby-1"); ok {
+		t.Error("Lobby should have been closed")
+	}
+}
+
+func TestJoinLobbySendsContentFilter(t *testing.T) {
+	s := NewServer()
+	s.Hub.SetContentFilter(game.NewContentFilter(map[string][]string{"en": {"heck"}}), domain.FilterMask)
+	lobby, _ := s.Hub.CreateLobby("lobby-1", nil)
+	client := &recordingClient{}
+	if err := s.joinLobby(lobby, &domain.Player{ID: "p1", Name: "Ana"}, client); err != nil {
+		t.Fatal(err)
+	}
+	for _, msg := range client.sent {
+		if event, ok := msg.(map[string]interface{}); ok && event["type"] == "LOBBY_CONFIG" {
+			if event["content_filter"] != domain.FilterMask {
+				t.Errorf("LOBBY_CONFIG on join = %v, want content_filter %q", event, domain.FilterMask)
+			}
+			return
+		}
+	}
+	t.Errorf("No LOBBY_CONFIG sent on join: %v", client.sent)
+}
+
+// recordingClient stands for a websocket connection in handler tests.
+type recordingClient struct {
+	sent   []any
+	closed bool
+}
+
+func (r *recordingClient) WriteJSON(v any) error {
+	r.sent = append(r.sent, v)
+	return nil
+}
+
+func (r *recordingClient) Close() error {
+	r.closed = true
+	return nil
+}
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"log"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
@@ -12,7 +14,17 @@ func (s *Server) createLobbyHandler(c *fiber.Ctx) error {
 	// Create empty lobby. The first player to connect will become the leader.
 	// Hub.CreateLobby is thread-safe.
 	// We pass nil as host because the host hasn't connected via WS yet.
-	_ = s.Hub.CreateLobby(id, nil)
+	lobby, err := s.Hub.CreateLobby(id, nil)
+	if err != nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "Too many active lobbies, try again later",
+		})
+	}
+
+	// Advertise this replica as the owner so players landing on other replicas are relayed here.
+	if err := s.hostLobby(lobby); err != nil {
+		log.Printf("Error claiming lobby %s on the backplane: %v", id, err)
+	}
 
 	return c.JSON(fiber.Map{
 		"lobby_id": id,
@@ -1,29 +1,112 @@
 package server
 
 import (
+	"errors"
+	"impostor/internal/domain"
 	"impostor/internal/game"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+func (s *Server) getLanguagesHandler(c *fiber.Ctx) error {
+	languages := game.AvailableLanguages()
+	for i, lang := range languages {
+		// "infinite" tells the client whether ✨ Infinite is listed, live or offline
+		languages[i].Datamuse = s.Hub.InfiniteServes(lang.Code)
+	}
+	return c.JSON(fiber.Map{
+		"languages": languages,
+	})
+}
+
 func (s *Server) getCategoriesHandler(c *fiber.Ctx) error {
 	language := c.Query("lang", "en") // Default to English
-	names := game.GetAllCategoryNames(language)
+	refs := s.Hub.CategoryRefs(language)
+
+	// Lobby members also see the custom categories uploaded by their leader
+	if lobby, ok := s.Hub.GetLobby(c.Query("lobby")); ok {
+		refs = append(refs, lobby.CustomCategoryRefs()...)
+	}
+
+	// ids[i] is the ID of categories[i]; unlike names, IDs are the same in every language
+	names := make([]string, len(refs))
+	ids := make([]string, len(refs))
+	for i, ref := range refs {
+		names[i], ids[i] = ref.Name, ref.ID
+	}
 	return c.JSON(fiber.Map{
 		"categories": names,
+		"ids":        ids,
 	})
 }
 
+// uploadCategoryHandler stores a custom category in a lobby. Only the lobby leader may upload,
+// authenticated with the token of the SESSION event: "Authorization: Bearer <token>".
+// Body: { "category": { "name": "...", "pairs": [{ "real": "...", "trap": "..." }] } }
+func (s *Server) uploadCategoryHandler(c *fiber.Ctx) error {
+	type UploadPayload struct {
+		Category domain.Category `json:"category"`
+	}
+	var payload UploadPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid body",
+		})
+	}
+
+	lobby, ok := s.Hub.GetLobby(c.Params("id"))
+	if !ok {
+		return s.lobbyNotFound(c, c.Params("id"))
+	}
+
+	playerID, ok := lobby.PlayerForToken(strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer "))
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "A player token of this lobby is required",
+		})
+	}
+
+	err := lobby.SetCustomCategory(playerID, payload.Category)
+	if errors.Is(err, game.ErrNotLeader) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"categories": lobby.CustomCategoryNames(),
+	})
+}
+
+// getRandomWordHandler draws a pair for the offline mode.
+// ?difficulty=hard only draws pairs of that difficulty.
+// ?exclude=Coffee,Dog skips pairs already played (by real word); when every pair of the category
+// has been played, "reshuffled" is true and the client should start a new history.
 func (s *Server) getRandomWordHandler(c *fiber.Ctx) error {
+	type WordResponse struct {
+		domain.WordPair
+		Reshuffled bool `json:"reshuffled"`
+	}
+
 	category := c.Query("category", "General")
 	lang := c.Query("lang", "en")
-
-	word, err := game.GetRandomWord(category, lang)
+	difficulty, err := game.ParseDifficulty(c.Query("difficulty"))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to get random word",
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
 		})
 	}
+	var exclude []string
+	if list := c.Query("exclude"); list != "" {
+		exclude = strings.Split(list, ",")
+	}
 
-	return c.JSON(word)
+	word, reshuffled := game.DrawWord(category, lang, difficulty, exclude)
+	return c.JSON(WordResponse{WordPair: word, Reshuffled: reshuffled})
 }
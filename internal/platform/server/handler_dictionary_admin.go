@@ -0,0 +1,268 @@
+package server
+
+import (
+	"errors"
+	"impostor/internal/domain"
+	"impostor/internal/game"
+	"net/url"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultPerPage = 50
+	maxPerPage     = 200
+)
+
+// setupDictionaryAdminRoutes registers the dictionary management endpoints under the admin group.
+func (s *Server) setupDictionaryAdminRoutes(admin fiber.Router) {
+	dict := admin.Group("/dictionary/:lang")
+	dict.Get("/categories", s.listCategoriesHandler)
+	dict.Post("/categories", s.createCategoryHandler)
+	dict.Get("/categories/:name", s.getCategoryHandler)
+	dict.Put("/categories/:name", s.updateCategoryHandler)
+	dict.Delete("/categories/:name", s.deleteCategoryHandler)
+	dict.Post("/categories/:name/pairs", s.addPairsHandler)
+	dict.Put("/categories/:name/pairs/:index", s.updatePairHandler)
+	dict.Delete("/categories/:name/pairs/:index", s.deletePairHandler)
+	dict.Get("/export", s.exportDictionaryHandler)
+	dict.Post("/import", s.importDictionaryHandler)
+}
+
+// page holds the pagination parameters of a listing (?page=1&per_page=50).
+type page struct {
+	Number  int `json:"page"`
+	PerPage int `json:"per_page"`
+	Total   int `json:"total"`
+}
+
+func parsePage(c *fiber.Ctx) page {
+	p := page{Number: c.QueryInt("page", 1), PerPage: c.QueryInt("per_page", defaultPerPage)}
+	if p.Number < 1 {
+		p.Number = 1
+	}
+	if p.PerPage < 1 || p.PerPage > maxPerPage {
+		p.PerPage = defaultPerPage
+	}
+	return p
+}
+
+// bounds returns the slice bounds of the current page over total items.
+func (p *page) bounds(total int) (int, int) {
+	p.Total = total
+	start := min((p.Number-1)*p.PerPage, total)
+	end := min(start+p.PerPage, total)
+	return start, end
+}
+
+// categoryParam returns the decoded :name parameter (category names may contain spaces or accents).
+func categoryParam(c *fiber.Ctx) string {
+	name, err := url.PathUnescape(c.Params("name"))
+	if err != nil {
+		return c.Params("name")
+	}
+	return name
+}
+
+// dictionaryError maps DictionaryManager errors to HTTP responses.
+func dictionaryError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusBadRequest
+	switch {
+	case errors.Is(err, game.ErrCategoryNotFound), errors.Is(err, game.ErrPairNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, game.ErrCategoryExists):
+		status = fiber.StatusConflict
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
+
+func (s *Server) listCategoriesHandler(c *fiber.Ctx) error {
+	type CategorySummary struct {
+		Name      string `json:"name"`
+		PairCount int    `json:"pair_count"`
+	}
+
+	categories, _ := game.CurrentDictionary().Lookup(c.Params("lang"))
+	query := strings.ToLower(c.Query("q"))
+	summaries := make([]CategorySummary, 0)
+	for _, cat := range categories {
+		if query != "" && !strings.Contains(strings.ToLower(cat.Name), query) {
+			continue
+		}
+		summaries = append(summaries, CategorySummary{Name: cat.Name, PairCount: len(cat.Pairs)})
+	}
+
+	p := parsePage(c)
+	start, end := p.bounds(len(summaries))
+	return c.JSON(fiber.Map{
+		"categories": summaries[start:end],
+		"pagination": p,
+	})
+}
+
+func (s *Server) getCategoryHandler(c *fiber.Ctx) error {
+	name := categoryParam(c)
+	categories, _ := game.CurrentDictionary().Lookup(c.Params("lang"))
+	var category *domain.Category
+	for _, cat := range categories {
+		if cat.Name == name {
+			category = &cat
+			break
+		}
+	}
+	if category == nil {
+		return dictionaryError(c, game.ErrCategoryNotFound)
+	}
+
+	// Each pair keeps its index in the category, which is what the pair endpoints expect.
+	type IndexedPair struct {
+		Index int `json:"index"`
+		domain.WordPair
+	}
+	query := strings.ToLower(c.Query("q"))
+	pairs := make([]IndexedPair, 0)
+	for i, pair := range category.Pairs {
+		if query != "" && !strings.Contains(strings.ToLower(pair.Real), query) &&
+			!strings.Contains(strings.ToLower(pair.Trap), query) {
+			continue
+		}
+		pairs = append(pairs, IndexedPair{Index: i, WordPair: pair})
+	}
+
+	p := parsePage(c)
+	start, end := p.bounds(len(pairs))
+	return c.JSON(fiber.Map{
+		"name":       category.Name,
+		"pairs":      pairs[start:end],
+		"pagination": p,
+	})
+}
+
+func (s *Server) createCategoryHandler(c *fiber.Ctx) error {
+	var cat domain.Category
+	if err := c.BodyParser(&cat); err != nil {
+		return dictionaryError(c, err)
+	}
+	if err := s.Dictionary.CreateCategory(c.Params("lang"), cat); err != nil {
+		return dictionaryError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(cat)
+}
+
+func (s *Server) updateCategoryHandler(c *fiber.Ctx) error {
+	var cat domain.Category
+	if err := c.BodyParser(&cat); err != nil {
+		return dictionaryError(c, err)
+	}
+	if err := s.Dictionary.UpdateCategory(c.Params("lang"), categoryParam(c), cat); err != nil {
+		return dictionaryError(c, err)
+	}
+	return c.JSON(cat)
+}
+
+func (s *Server) deleteCategoryHandler(c *fiber.Ctx) error {
+	if err := s.Dictionary.DeleteCategory(c.Params("lang"), categoryParam(c)); err != nil {
+		return dictionaryError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (s *Server) addPairsHandler(c *fiber.Ctx) error {
+	type PairsPayload struct {
+		Pairs []domain.WordPair `json:"pairs"`
+	}
+	var payload PairsPayload
+	if err := c.BodyParser(&payload); err != nil || len(payload.Pairs) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "pairs are required",
+		})
+	}
+	if err := s.Dictionary.AddPairs(c.Params("lang"), categoryParam(c), payload.Pairs); err != nil {
+		return dictionaryError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(payload)
+}
+
+func (s *Server) updatePairHandler(c *fiber.Ctx) error {
+	index, err := c.ParamsInt("index")
+	if err != nil {
+		return dictionaryError(c, game.ErrPairNotFound)
+	}
+	var pair domain.WordPair
+	if err := c.BodyParser(&pair); err != nil {
+		return dictionaryError(c, err)
+	}
+	if err := s.Dictionary.UpdatePair(c.Params("lang"), categoryParam(c), index, pair); err != nil {
+		return dictionaryError(c, err)
+	}
+	return c.JSON(pair)
+}
+
+func (s *Server) deletePairHandler(c *fiber.Ctx) error {
+	index, err := c.ParamsInt("index")
+	if err != nil {
+		return dictionaryError(c, game.ErrPairNotFound)
+	}
+	if err := s.Dictionary.DeletePair(c.Params("lang"), categoryParam(c), index); err != nil {
+		return dictionaryError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// exportDictionaryHandler returns a language in the dictionary file format (?format=yaml for YAML).
+func (s *Server) exportDictionaryHandler(c *fiber.Ctx) error {
+	lang := c.Params("lang")
+	categories, ok := game.CurrentDictionary().Lookup(lang)
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Language not found",
+		})
+	}
+	export := fiber.Map{
+		"language":   lang,
+		"categories": categories,
+	}
+	if themes := game.CurrentDictionary().Themes(lang); len(themes) > 0 {
+		export["infinite_themes"] = themes
+	}
+
+	if c.Query("format") == "yaml" {
+		data, err := yaml.Marshal(export)
+		if err != nil {
+			return err
+		}
+		c.Set(fiber.HeaderContentType, "application/yaml")
+		return c.Send(data)
+	}
+	return c.JSON(export)
+}
+
+// importDictionaryHandler adds categories in bulk from a body in the dictionary file format.
+// ?mode=replace replaces the whole language; the default merges by category name.
+func (s *Server) importDictionaryHandler(c *fiber.Ctx) error {
+	type ImportPayload struct {
+		Categories []domain.Category `json:"categories" yaml:"categories"`
+	}
+	var payload ImportPayload
+	var err error
+	if strings.Contains(c.Get(fiber.HeaderContentType), "yaml") {
+		err = yaml.Unmarshal(c.Body(), &payload)
+	} else {
+		err = c.BodyParser(&payload)
+	}
+	if err != nil {
+		return dictionaryError(c, err)
+	}
+
+	replace := c.Query("mode") == "replace"
+	if err := s.Dictionary.Import(c.Params("lang"), payload.Categories, replace); err != nil {
+		return dictionaryError(c, err)
+	}
+	return c.JSON(fiber.Map{
+		"imported": len(payload.Categories),
+	})
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"encoding/json"
+	"impostor/internal/game"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func adminRequest(t *testing.T, s *Server, method, target, body string) *http.Response {
+	t.Helper()
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, target, reader)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.App.Test(req)
+	if err != nil {
+		t.Fatalf("App.Test error: %v", err)
+	}
+	return resp
+}
+
+func TestDictionaryAdminCRUD(t *testing.T) {
+	original := game.CurrentDictionary()
+	defer game.SetDictionary(original)
+	s := newAdminTestServer()
+
+	// Create
+	resp := adminRequest(t, s, "POST", "/api/admin/dictionary/en/categories",
+		`{"name": "Office", "pairs": [{"real": "Stapler", "trap": "Clip"}, {"real": "Desk", "trap": "Table"}]}`)
+	if resp.StatusCode != 201 {
+		t.Fatalf("Expected status 201, got %d", resp.StatusCode)
+	}
+	resp = adminRequest(t, s, "POST", "/api/admin/dictionary/en/categories", `{"name": "Office", "pairs": [{"real": "A", "trap": "B"}]}`)
+	if resp.StatusCode != 409 {
+		t.Errorf("Expected status 409 for duplicate category, got %d", resp.StatusCode)
+	}
+	resp = adminRequest(t, s, "POST", "/api/admin/dictionary/en/categories/Office/pairs", `{"pairs": [{"real": "Pen", "trap": "pen"}]}`)
+	if resp.StatusCode != 400 {
+		t.Errorf("Expected status 400 for real == trap, got %d", resp.StatusCode)
+	}
+
+	// Visible to players immediately
+	found := false
+	for _, name := range game.GetAllCategoryNames("en") {
+		found = found || name == "Office"
+	}
+	if !found {
+		t.Error("New category not listed by GetAllCategoryNames")
+	}
+
+	// Search and pagination
+	resp = adminRequest(t, s, "GET", "/api/admin/dictionary/en/categories/Office?q=desk&per_page=1", "")
+	var detail struct {
+		Pairs []struct {
+			Index int    `json:"index"`
+			Real  string `json:"real"`
+		} `json:"pairs"`
+		Pagination page `json:"pagination"`
+	}
+	json.NewDecoder(resp.Body).Decode(&detail)
+	if detail.Pagination.Total != 1 || len(detail.Pairs) != 1 || detail.Pairs[0].Index != 1 {
+		t.Errorf("Unexpected search result: %+v", detail)
+	}
+
+	// Update and delete pairs
+	resp = adminRequest(t, s, "PUT", "/api/admin/dictionary/en/categories/Office/pairs/1", `{"real": "Desk", "trap": "Counter"}`)
+	if resp.StatusCode != 200 {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if got := game.GetCategoryByName("Office", "en").Pairs[1].Trap; got != "Counter" {
+		t.Errorf("Pair not updated, got %q", got)
+	}
+	resp = adminRequest(t, s, "DELETE", "/api/admin/dictionary/en/categories/Office/pairs/5", "")
+	if resp.StatusCode != 404 {
+		t.Errorf("Expected status 404 for missing pair, got %d", resp.StatusCode)
+	}
+
+	// Delete
+	resp = adminRequest(t, s, "DELETE", "/api/admin/dictionary/en/categories/Office", "")
+	if resp.StatusCode != 204 {
+		t.Errorf("Expected status 204, got %d", resp.StatusCode)
+	}
+	if got := game.GetCategoryByName("Office", "en").Name; got == "Office" {
+		t.Error("Category was not deleted")
+	}
+}
+
+func TestDictionaryAdminImportExport(t *testing.T) {
+	original := game.CurrentDictionary()
+	defer game.SetDictionary(original)
+	s := newAdminTestServer()
+
+	resp := adminRequest(t, s, "POST", "/api/admin/dictionary/it/import",
+		`{"categories": [{"name": "Pausa caffè", "pairs": [{"real": "Cappuccino", "trap": "Macchiato"}]}]}`)
+	if resp.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	resp = adminRequest(t, s, "GET", "/api/admin/dictionary/it/export", "")
+	var export struct {
+		Language   string `json:"language"`
+		Categories []struct {
+			Name string `json:"name"`
+		} `json:"categories"`
+	}
+	json.NewDecoder(resp.Body).Decode(&export)
+	if export.Language != "it" || len(export.Categories) != 1 || export.Categories[0].Name != "Pausa caffè" {
+		t.Errorf("Unexpected export: %+v", export)
+	}
+
+	// Names with spaces and accents are URL-encoded
+	resp = adminRequest(t, s, "GET", "/api/admin/dictionary/it/categories/"+url.PathEscape("Pausa caffè"), "")
+	if resp.StatusCode != 200 {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+}
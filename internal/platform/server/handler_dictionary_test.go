@@ -3,7 +3,11 @@ package server
 import (
 	"encoding/json"
 	"impostor/internal/domain"
+	"impostor/internal/game"
 	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -61,3 +65,161 @@ func TestGetCategoriesHandler(t *testing.T) {
 		t.Error("Expected categories list, got empty")
 	}
 }
+
+func TestGetLanguagesHandler(t *testing.T) {
+	s := NewServer()
+
+	resp, err := s.App.Test(httptest.NewRequest("GET", "/api/languages", nil))
+	if err != nil {
+		t.Fatalf("App.Test error: %v", err)
+	}
+
+	var result struct {
+		Languages []struct {
+			Code string `json:"code"`
+			Name string `json:"name"`
+		} `json:"languages"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	codes := make(map[string]string)
+	for _, lang := range result.Languages {
+		codes[lang.Code] = lang.Name
+	}
+	for _, code := range []string{"en", "es", "fr", "de", "pt"} {
+		if codes[code] == "" {
+			t.Errorf("Expected language %q to be listed, got %v", code, codes)
+		}
+	}
+}
+
+func TestGetRandomWordHandlerExclude(t *testing.T) {
+	s := NewServer()
+
+	// Every Animals pair but one is excluded
+	cat := game.GetCategoryByName("Animals", "en")
+	exclude := make([]string, 0, len(cat.Pairs))
+	for _, pair := range cat.Pairs[1:] {
+		exclude = append(exclude, pair.Real)
+	}
+
+	var word struct {
+		Real       string `json:"real"`
+		Reshuffled bool   `json:"reshuffled"`
+	}
+	resp, _ := s.App.Test(httptest.NewRequest("GET", "/api/word?category=Animals&lang=en&exclude="+url.QueryEscape(strings.Join(exclude, ",")), nil))
+	json.NewDecoder(resp.Body).Decode(&word)
+	if word.Real != cat.Pairs[0].Real || word.Reshuffled {
+		t.Errorf("Expected the only pair left (%s), got %+v", cat.Pairs[0].Real, word)
+	}
+
+	// Everything played: the deck starts over
+	exclude = append(exclude, cat.Pairs[0].Real)
+	resp, _ = s.App.Test(httptest.NewRequest("GET", "/api/word?category=Animals&lang=en&exclude="+url.QueryEscape(strings.Join(exclude, ",")), nil))
+	json.NewDecoder(resp.Body).Decode(&word)
+	if !word.Reshuffled {
+		t.Error("Expected reshuffled once every pair was excluded")
+	}
+}
+
+func TestGetRandomWordHandlerDifficulty(t *testing.T) {
+	s := NewServer()
+
+	resp, _ := s.App.Test(httptest.NewRequest("GET", "/api/word?category=Animals&lang=en&difficulty=easy", nil))
+	var word domain.WordPair
+	json.NewDecoder(resp.Body).Decode(&word)
+	if word.Difficulty != domain.DifficultyEasy {
+		t.Errorf("Expected an easy pair, got %+v", word)
+	}
+
+	resp, _ = s.App.Test(httptest.NewRequest("GET", "/api/word?difficulty=brutal", nil))
+	if resp.StatusCode != 400 {
+		t.Errorf("Expected status 400 for an unknown difficulty, got %d", resp.StatusCode)
+	}
+}
+
+func TestGetCategoriesHandlerIDs(t *testing.T) {
+	s := NewServer()
+
+	ids := func(lang string) []string {
+		resp, _ := s.App.Test(httptest.NewRequest("GET", "/api/categories?lang="+lang, nil))
+		var result map[string][]string
+		json.NewDecoder(resp.Body).Decode(&result)
+		if len(result["ids"]) != len(result["categories"]) {
+			t.Fatalf("ids and categories should be aligned: %v", result)
+		}
+		return result["ids"]
+	}
+
+	en, es := ids("en"), ids("es")
+	if strings.Join(en, ",") != strings.Join(es, ",") {
+		t.Errorf("Expected the same category IDs in every language, got %v and %v", en, es)
+	}
+
+	// Datamuse has no French vocabulary and no association graph is loaded
+	if fr := ids("fr"); slices.Contains(fr, game.InfiniteCategoryID) || !slices.Contains(en, game.InfiniteCategoryID) {
+		t.Errorf("Expected Infinite listed in English only, got %v and %v", en, fr)
+	}
+}
+
+func TestUploadCategoryHandler(t *testing.T) {
+	s := NewServer()
+	lobby, _ := s.Hub.CreateLobby("lobby-1", nil)
+	tokens := make(map[string]string)
+	for _, id := range []string{"leader", "guest"} {
+		client := &recordingClient{}
+		if err := s.joinLobby(lobby, &domain.Player{ID: id, Name: id}, client); err != nil {
+			t.Fatal(err)
+		}
+		for _, msg := range client.sent {
+			if event, ok := msg.(map[string]interface{}); ok && event["type"] == "SESSION" {
+				tokens[id], _ = event["token"].(string)
+			}
+		}
+		if tokens[id] == "" {
+			t.Fatalf("%s received no SESSION token: %v", id, client.sent)
+		}
+	}
+
+	upload := func(token, body string) int {
+		req := httptest.NewRequest("POST", "/api/lobby/lobby-1/categories", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, _ := s.App.Test(req)
+		return resp.StatusCode
+	}
+	project := `{"category": {"name": "Project", "pairs": [{"real": "Phoenix", "trap": "Falcon"}]}}`
+
+	for _, tt := range []struct {
+		name, token, body string
+		want              int
+	}{
+		{"public player ID", "", `{"player_id": "leader", "category": {"name": "Project", "pairs": [{"real": "A", "trap": "B"}]}}`, 401},
+		{"player ID as token", "leader", project, 401},
+		{"guest", tokens["guest"], project, 403},
+		{"dictionary name", tokens["leader"], `{"category": {"name": "Animals", "pairs": [{"real": "A", "trap": "B"}]}}`, 400},
+		{"leader", tokens["leader"], project, 201},
+	} {
+		if got := upload(tt.token, tt.body); got != tt.want {
+			t.Errorf("%s: expected status %d, got %d", tt.name, tt.want, got)
+		}
+	}
+
+	resp, _ := s.App.Test(httptest.NewRequest("GET", "/api/categories?lang=en&lobby=lobby-1", nil))
+	var result map[string][]string
+	json.NewDecoder(resp.Body).Decode(&result)
+	seen := make(map[string]bool)
+	for _, name := range result["categories"] {
+		if seen[name] {
+			t.Errorf("category %q listed twice", name)
+		}
+		seen[name] = true
+	}
+	if !seen["Project"] {
+		t.Errorf("custom category missing from %v", result["categories"])
+	}
+}
@@ -1,15 +1,51 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"impostor/internal/domain"
 	"impostor/internal/game"
 	"log"
+	"sync"
 
 	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
 )
 
+// wsClient wraps a websocket connection so that concurrent writers
+// (broadcasts from other players, direct replies from the read loop) don't interleave frames.
+type wsClient struct {
+	conn *websocket.Conn
+	mu   sync.Mutex
+}
+
+func (w *wsClient) WriteJSON(v any) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.conn.WriteJSON(v)
+}
+
+func (w *wsClient) Close() error {
+	return w.conn.Close()
+}
+
+// errorEvent builds the ERROR event sent to a single client.
+func errorEvent(code, message string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":    "ERROR",
+		"code":    code,
+		"message": message,
+	}
+}
+
+// sendError replies to a single client with an ERROR event.
+func sendError(client game.NetworkClient, code, message string) {
+	if err := client.WriteJSON(errorEvent(code, message)); err != nil {
+		log.Printf("Error sending error event: %v", err)
+	}
+}
+
 // setupWebsocketRoutes configures the WS endpoints.
 func (s *Server) setupWebsocketRoutes() {
 	// Middleware to check if it's a websocket upgrade
@@ -21,10 +57,11 @@ func (s *Server) setupWebsocketRoutes() {
 		return fiber.ErrUpgradeRequired
 	})
 
-	s.App.Get("/ws/:lobbyId", websocket.New(func(c *websocket.Conn) {
+	s.App.Get("/ws/:lobbyId", ipLimiter(s.Config.RateLimit.JoinsPerMinute), websocket.New(func(c *websocket.Conn) {
 		lobbyID := c.Params("lobbyId")
 		playerID := c.Query("playerId")
 		playerName := c.Query("playerName")
+		language := c.Query("lang") // Optional, the lobby language when empty
 
 		if playerID == "" || playerName == "" {
 			log.Println("Missing player info")
@@ -33,192 +70,296 @@ func (s *Server) setupWebsocketRoutes() {
 		}
 
 		log.Printf("Player %s connecting to lobby %s", playerName, lobbyID)
+		client := &wsClient{conn: c}
 
 		// 1. Get Lobby (Strict Mode: Must exist)
 		lobby, ok := s.Hub.GetLobby(lobbyID)
 		if !ok {
+			// The lobby may live on another replica, in which case we relay through the backplane.
+			if s.isHostedElsewhere(lobbyID) {
+				s.serveRemotePlayer(client, lobbyID, playerID, playerName, language)
+				return
+			}
 			// For strictly generated UUIDs, we should fail if not found.
-			// sending close message or just closing.
 			log.Printf("Lobby %s not found", lobbyID)
 			c.Close()
 			return
 		}
 
-		// Determine if this player should be the leader (if lobby has no players yet)
-		// We need to check this safely.
-		// Since we can't lock the lobby from here easily without exposing Mutex,
-		// we should rely on AddPlayer logic or check safe copy.
-		// BETTER: Update Hub/Lobby `AddPlayer` to return "isLeader" status or handle it internally.
-		// For this MVP, let's assume if count == 0, isLeader = true.
-		// But AddPlayer is void.
-		// Let's modify logic: Just create the player struct here.
-		// We need to know if they are the FIRST one.
-		// HACK: We can check len(lobby.Players) but it's racy without lock. 
-		// Since AddPlayer locks, we should move Leader assignment INSIDE AddPlayer?
-		// or make AddPlayer smart.
-		
-		// Let's stick to safe logic using the public methods we have.
-		// We can't change domain.Player inside AddPlayer easily if it's passed by pointer? 
-		// Yes we can.
-		
-		// Let's trust the "CreateLobby" from API created an empty lobby.
-		// But wait, `Hub.GetLobby` uses RLock.
-		// If 2 players join simultaneously, they both might see count 0?
-		// No, `AddPlayer` has a Write Lock.
-		
-		// Let's refine Handshake:
 		p := &domain.Player{
 			ID:       playerID,
 			Name:     playerName,
+			Language: language,
 			IsAlive:  true,
-			IsLeader: false, // Default
-		}
-		
-		isFirst := lobby.AddPlayerSafe(p) // We need this new method to be atomic
-		if isFirst {
-			p.IsLeader = true
-		}
-		
-		// Register connection for broadcasting
-		lobby.RegisterClient(playerID, c)
-
-		// Broadcast updated player list
-		// In a real app we'd need a cleaner DTO approach
-		// We can't access lobby.Players safely without lock, but we are outside lock here.
-		// Wait, RegisterClient uses lock. We need a method on Lobby to "GetState" or "BroadcastState".
-		// Let's create a quick valid map to send.
-		// ACTUALLY: Lobby.Broadcast is generic. We need to fetch data safely.
-		// Let's just send a simple "Player Joined" message for now, or even better:
-		// Modify Lobby to have a method "BroadcastPlayerList".
-		
-		// For MVP, since we don't have that method, let's just assume we send a basic signal 
-		// and the frontend will request state, or we send it here (risky without lock).
-		// Best approach: Add `BroadcastGameState` to Lobby struct in next step.
-		// For now, let's send a manual JSON with what we know.
-		
-		// Broadcast updated player list (includes the new player)
-		// This ensures the new player gets the full list of existing players,
-		// and existing players see the new one.
-		lobby.BroadcastPlayerList()
-
-		defer func() {
-			// Cleanup on disconnect
-			isEmpty := lobby.RemovePlayer(playerID)
-			if isEmpty {
-				log.Printf("Lobby %s empty, deleting...", lobbyID)
-				s.Hub.DeleteLobby(lobbyID) // We need to expose this or use safe method
-			} else {
-				// Broadcast player left event to updating remaining clients
-				// We need to fetch the updated list or just send the ID of who left.
-				// Ideally, we also send the NEW leader if changed.
-				// Let's enable a broadcast of the full player list to be safe.
-				// This requires a helper "BroadcastPlayerList".
-				// For now:
-				leaveMsg := map[string]interface{}{
-					"type": "PLAYER_LEFT",
-					"player_id": playerID,
-				}
-				lobby.Broadcast(leaveMsg)
-				
-				// Also send an update for the new leader? 
-				// The client can deduce it if we send the full list.
-				// Let's modify RemovePlayer to return the new leader ID or boolean?
-				// Simpler: Just force a full refresh of players.
-				// Since we can't easily get the list safely here without another lock,
-				// let's rely on the frontend to remove the player ID from its list,
-				// AND check if the leader is gone.
-				
-				// Actually, `RemovePlayer` logic assigned a new leader.
-				// We should probably broadcast the FULL list to ensure sync.
-				// Let's implement `BroadcastPlayerList` on Lobby.
-				lobby.BroadcastPlayerList() 
-			}
-		}()
-
-		// 2. Read Loop
-		// In a real app, we would have a 'Client' struct managing the connection write pump too.
-		// For this MVP, we just read.
-		var (
-			msg []byte
-			err error
-		)
-		for {
-			if _, msg, err = c.ReadMessage(); err != nil {
-				log.Println("read:", err)
-				break
-			}
-			log.Printf("recv: %s", msg)
+			IsLeader: false, // AddPlayerSafe promotes the first player
+		}
+		if err := s.joinLobby(lobby, p, client); err != nil {
+			log.Printf("Player %s rejected from lobby %s: %v", playerName, lobbyID, err)
+			c.Close()
+			return
+		}
 
-			// Handle messages (e.g., START_GAME)
-			// Simple JSON command structure
-			type Command struct {
-				Action string `json:"action"`
-			}
-			var cmd Command
-			if json.Unmarshal(msg, &cmd) == nil {
-				if cmd.Action == "START_GAME" {
-					// Parse Start Options
-					type StartPayload struct {
-						Mode     string `json:"mode"`     // "easy" or "hard"
-						Category string `json:"category"` // Category name
-						Language string `json:"language"` // "en" or "es"
-					}
-					var startOpts StartPayload
-					json.Unmarshal(msg, &startOpts)
-
-					// Default to Hard (Standard)
-					gameMode := domain.ModeHard
-					if startOpts.Mode == "easy" {
-						gameMode = domain.ModeEasy
-					}
-
-					// Default language to English
-					language := startOpts.Language
-					if language == "" {
-						language = "en"
-					}
-
-					// Store language in lobby config
-					lobby.Config.Language = language
-
-					// Get selected category or default
-					cat := game.GetCategoryByName(startOpts.Category, language)
-
-					// Note: StartGame signature needs to accept GameMode if it doesn't already.
-					// Reviewing match.go: func (l *Lobby) StartGame(category domain.Category) error
-					// We need to update StartGame to take the mode!
-					if err := lobby.StartGame(cat, gameMode); err != nil {
-						log.Printf("Error starting game: %v", err)
-					} else {
-						log.Println("Game Started and broadcasted!")
-					}
-				} else if cmd.Action == "CHAT_MESSAGE" {
-					// Broadcast chat message
-					// Payload expected: { action: "CHAT_MESSAGE", message: "text" }
-					type ChatPayload struct {
-						Message string `json:"message"`
-					}
-					var chatPayload ChatPayload
-					json.Unmarshal(msg, &chatPayload)
-
-					chatMsg := map[string]interface{}{
-						"type": "CHAT_MESSAGE",
-						"from": playerName,
-						"text": chatPayload.Message,
-					}
-					lobby.Broadcast(chatMsg)
-				} else if cmd.Action == "CAST_VOTE" {
-					type VotePayload struct {
-						TargetID string `json:"target_id"`
-					}
-					var votePayload VotePayload
-					json.Unmarshal(msg, &votePayload)
-					
-					lobby.CastVote(playerID, votePayload.TargetID)
-				} else if cmd.Action == "RESET_GAME" {
-					lobby.ResetGame()
-				}
+		// Cleanup on disconnect
+		defer s.leaveLobby(lobby, playerID, client)
+
+		// 2. Read Loop, commands are canceled when the player disconnects
+		ctx, cancel := context.WithCancel(s.ctx)
+		defer cancel()
+		s.readLoop(client, playerName, func(msg []byte) {
+			s.handleCommand(ctx, lobby, playerID, playerName, msg)
+		})
+	}))
+}
+
+// joinLobby adds the player to the lobby and registers the connection used to reach them.
+// If the player is rejected, the client is told why and the error is returned.
+func (s *Server) joinLobby(lobby *game.Lobby, p *domain.Player, client game.NetworkClient) error {
+	isFirst, err := lobby.AddPlayerSafe(p) // Atomic: only one player can be first
+	if errors.Is(err, game.ErrLobbyFull) {
+		sendError(client, "LOBBY_FULL", "This lobby is full")
+		return err
+	}
+	if errors.Is(err, game.ErrPlayerKicked) {
+		sendError(client, "KICKED", "You were kicked from this lobby")
+		return err
+	}
+	if errors.Is(err, game.ErrContentRejected) {
+		sendError(client, "NAME_REJECTED", "This name is not allowed in this lobby")
+		return err
+	}
+	if err != nil {
+		sendError(client, "JOIN_FAILED", err.Error())
+		return err
+	}
+	if isFirst {
+		p.IsLeader = true
+	}
+
+	// Register connection for broadcasting
+	lobby.RegisterClient(p.ID, client)
+
+	// The token proves who the player is to the REST API; unlike their ID, only they receive it
+	token, err := lobby.IssueToken(p.ID)
+	if err != nil {
+		log.Printf("Error issuing a token to player %s: %v", p.ID, err)
+	} else if err := client.WriteJSON(map[string]interface{}{"type": "SESSION", "token": token}); err != nil {
+		log.Printf("Error sending the token to player %s: %v", p.ID, err)
+	}
+
+	// Broadcast updated player list (includes the new player)
+	// This ensures the new player gets the full list of existing players,
+	// and existing players see the new one.
+	lobby.BroadcastPlayerList()
+
+	// LOBBY_CONFIG is only broadcast on changes, so tell the newcomer the current content filter
+	if err := client.WriteJSON(map[string]interface{}{"type": "LOBBY_CONFIG", "content_filter": lobby.ContentFilterMode()}); err != nil {
+		log.Printf("Error sending the lobby config to player %s: %v", p.ID, err)
+	}
+	return nil
+}
+
+// leaveLobby removes a player whose client disconnected and deletes the lobby once it is empty.
+// Nothing happens if the player already reconnected with another client; a nil client matches any.
+func (s *Server) leaveLobby(lobby *game.Lobby, playerID string, client game.NetworkClient) {
+	left, isEmpty := lobby.Disconnect(playerID, client)
+	if !left {
+		return
+	}
+	if isEmpty {
+		log.Printf("Lobby %s empty, deleting...", lobby.ID)
+		s.Hub.DeleteLobby(lobby.ID)
+		return
+	}
+
+	// Broadcast player left event to update remaining clients
+	leaveMsg := map[string]interface{}{
+		"type":      "PLAYER_LEFT",
+		"player_id": playerID,
+	}
+	lobby.Broadcast(leaveMsg)
+
+	// Disconnect may have assigned a new leader, so send the full list to keep everyone in sync.
+	lobby.BroadcastPlayerList()
+}
+
+// readLoop reads commands from the connection until it fails, enforcing the per-connection
+// rate limits before handing each message to dispatch.
+func (s *Server) readLoop(client *wsClient, playerName string, dispatch func(msg []byte)) {
+	limits := newConnLimiter(s.Config.RateLimit)
+
+	for {
+		_, msg, err := client.conn.ReadMessage()
+		if err != nil {
+			log.Println("read:", err)
+			return
+		}
+		log.Printf("recv: %s", msg)
+
+		// Malformed messages are not dispatched, but they still count against the limits
+		var cmd struct {
+			Action string `json:"action"`
+		}
+		malformed := json.Unmarshal(msg, &cmd) != nil
+
+		if !limits.Allow(cmd.Action) {
+			if limits.Exceeded() {
+				log.Printf("Player %s exceeded rate limits, disconnecting", playerName)
+				sendError(client, "RATE_LIMITED", "Too many messages, disconnecting")
+				return
 			}
+			sendError(client, "RATE_LIMITED", "You are sending messages too fast")
+			continue
 		}
-	}))
+		if malformed {
+			continue
+		}
+
+		dispatch(msg)
+	}
+}
+
+// handleCommand applies a single client command to the lobby.
+// It is used both for local connections and for players relayed from other replicas.
+func (s *Server) handleCommand(ctx context.Context, lobby *game.Lobby, playerID, playerName string, msg []byte) {
+	// Any message shows the players are still there, even if it changes nothing
+	lobby.Touch()
+
+	// Simple JSON command structure
+	type Command struct {
+		Action string `json:"action"`
+	}
+	var cmd Command
+	if json.Unmarshal(msg, &cmd) != nil {
+		return
+	}
+
+	if cmd.Action == "START_GAME" {
+		// Parse Start Options
+		type StartPayload struct {
+			Mode       string `json:"mode"`       // "easy", "easy_hint" or "hard"
+			Category   string `json:"category"`   // Category name or ID
+			Language   string `json:"language"`   // Language code, see /api/languages
+			Difficulty string `json:"difficulty"` // "easy", "medium", "hard" or empty for any
+			Adaptive   bool   `json:"adaptive"`   // Harder pairs when civilians keep winning, easier when the impostor does
+		}
+		var startOpts StartPayload
+		json.Unmarshal(msg, &startOpts)
+
+		// Default to Hard (Standard)
+		gameMode := domain.ModeHard
+		switch startOpts.Mode {
+		case "easy":
+			gameMode = domain.ModeEasy
+		case "easy_hint":
+			gameMode = domain.ModeEasyHint
+		}
+
+		// Default language to English
+		language := startOpts.Language
+		if language == "" {
+			language = "en"
+		}
+
+		difficulty, err := game.ParseDifficulty(startOpts.Difficulty)
+		if err != nil {
+			lobby.SendTo(playerID, errorEvent("INVALID_CONFIG", err.Error()))
+			return
+		}
+
+		settings := game.GameSettings{
+			Mode:       gameMode,
+			Category:   startOpts.Category,
+			Language:   language,
+			Difficulty: difficulty,
+			Adaptive:   startOpts.Adaptive,
+		}
+		if err := lobby.StartGameWith(ctx, settings); err != nil {
+			log.Printf("Error starting game: %v", err)
+		} else {
+			log.Println("Game Started and broadcasted!")
+		}
+	} else if cmd.Action == "CHAT_MESSAGE" {
+		// Broadcast chat message
+		// Payload expected: { action: "CHAT_MESSAGE", message: "text" }
+		type ChatPayload struct {
+			Message string `json:"message"`
+		}
+		var chatPayload ChatPayload
+		json.Unmarshal(msg, &chatPayload)
+
+		// Sent from the name the player joined with, screened by the lobby's content filter
+		err := lobby.Chat(playerID, chatPayload.Message)
+		if errors.Is(err, game.ErrContentRejected) {
+			lobby.SendTo(playerID, errorEvent("CONTENT_REJECTED", "Your message was not sent: it contains a blocked word"))
+		} else if err != nil {
+			log.Printf("Chat from %s in lobby %s dropped: %v", playerName, lobby.ID, err)
+		}
+	} else if cmd.Action == "CAST_VOTE" {
+		type VotePayload struct {
+			TargetID string `json:"target_id"`
+		}
+		var votePayload VotePayload
+		json.Unmarshal(msg, &votePayload)
+
+		lobby.CastVote(playerID, votePayload.TargetID)
+	} else if cmd.Action == "RESET_GAME" {
+		lobby.ResetGame()
+	} else if cmd.Action == "SET_MAX_PLAYERS" {
+		type MaxPlayersPayload struct {
+			MaxPlayers int `json:"max_players"`
+		}
+		var maxPayload MaxPlayersPayload
+		json.Unmarshal(msg, &maxPayload)
+
+		if err := lobby.SetMaxPlayers(playerID, maxPayload.MaxPlayers); err != nil {
+			lobby.SendTo(playerID, errorEvent("INVALID_CONFIG", err.Error()))
+		}
+	} else if cmd.Action == "SET_CONTENT_FILTER" {
+		// Payload expected: { action: "SET_CONTENT_FILTER", mode: "off" | "mask" | "reject" }
+		type FilterPayload struct {
+			Mode string `json:"mode"`
+		}
+		var filterPayload FilterPayload
+		json.Unmarshal(msg, &filterPayload)
+
+		mode, err := game.ParseContentFilter(filterPayload.Mode)
+		if err == nil {
+			err = lobby.SetContentFilter(playerID, mode)
+		}
+		if err != nil {
+			lobby.SendTo(playerID, errorEvent("INVALID_CONFIG", err.Error()))
+		}
+	} else if cmd.Action == "UPLOAD_CATEGORY" {
+		// Payload expected: { action: "UPLOAD_CATEGORY", category: { name, pairs: [{ real, trap }] } }
+		type UploadPayload struct {
+			Category domain.Category `json:"category"`
+		}
+		var upload UploadPayload
+		json.Unmarshal(msg, &upload)
+
+		if err := lobby.SetCustomCategory(playerID, upload.Category); err != nil {
+			lobby.SendTo(playerID, errorEvent("INVALID_CATEGORY", err.Error()))
+		}
+	} else if cmd.Action == "RATE_PAIR" {
+		// Payload expected: { action: "RATE_PAIR", rating: "up" | "down" }, once the game is finished
+		type RatePayload struct {
+			Rating string `json:"rating"`
+		}
+		var ratePayload RatePayload
+		json.Unmarshal(msg, &ratePayload)
+
+		var rating int
+		switch ratePayload.Rating {
+		case "up":
+			rating = 1
+		case "down":
+			rating = -1
+		default:
+			lobby.SendTo(playerID, errorEvent("INVALID_RATING", `rating must be "up" or "down"`))
+			return
+		}
+		if err := lobby.RatePair(playerID, rating); err != nil {
+			lobby.SendTo(playerID, errorEvent("INVALID_RATING", err.Error()))
+		}
+	}
 }
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"encoding/json"
+	"impostor/internal/domain"
+	"impostor/internal/game"
+	"impostor/internal/game/datamusetest"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func getHealth(t *testing.T, s *Server) (health struct {
+	Status   string              `json:"status"`
+	Datamuse game.DatamuseHealth `json:"datamuse"`
+}) {
+	t.Helper()
+	resp, err := s.App.Test(httptest.NewRequest("GET", "/health", nil))
+	if err != nil {
+		t.Fatalf("App.Test error: %v", err)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return health
+}
+
+func TestHealthReportsDatamuseFallback(t *testing.T) {
+	fake := datamusetest.NewServer(t)
+	fake.SetMode(datamusetest.Failing)
+	cfg := DefaultConfig()
+	cfg.DatamuseURL = fake.URL
+	cfg.DatamuseBreakerThreshold = 1
+	s := NewServerWithConfig(cfg)
+
+	if h := getHealth(t, s); h.Status != "ok" || h.Datamuse.Status != "ok" {
+		t.Errorf("health before any Datamuse call = %+v", h)
+	}
+
+	s.datamuse.Prefetch("en")
+	deadline := time.Now().Add(2 * time.Second)
+	for getHealth(t, s).Datamuse.Status != "fallback" {
+		if time.Now().After(deadline) {
+			t.Fatalf("health never reported the fallback: %+v", getHealth(t, s))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The server itself stays healthy
+	if h := getHealth(t, s); h.Status != "ok" || h.Datamuse.Breaker.State != game.BreakerOpen {
+		t.Errorf("health = %+v", h)
+	}
+}
+
+func TestShutdownStopsDatamuseRefills(t *testing.T) {
+	fake := datamusetest.NewServer(t)
+	cfg := DefaultConfig()
+	cfg.DatamuseURL = fake.URL
+	s := NewServerWithConfig(cfg)
+
+	if err := s.Shutdown(); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	s.datamuse.Start(s.ctx)
+	s.datamuse.Prefetch("en")
+	time.Sleep(20 * time.Millisecond)
+	if n := len(fake.Requests()); n != 0 {
+		t.Errorf("%d Datamuse requests after Shutdown, want 0", n)
+	}
+}
+
+func TestShutdownSavesRatings(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.RatingsFile = filepath.Join(t.TempDir(), "ratings.json")
+	s := NewServerWithConfig(cfg)
+	defer game.SetPairRatings(nil)
+
+	s.ratings.Vote(game.PairVote{Lobby: "lobby-1", Language: "en", Category: "animals", Pair: domain.WordPair{ID: "dog-wolf"}, Rating: -1})
+	if err := s.Shutdown(); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if _, err := os.Stat(cfg.RatingsFile); err != nil {
+		t.Errorf("pending votes were not saved: %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"errors"
+	"impostor/internal/game"
+	"io/fs"
+	"log"
+	"net/http"
+)
+
+// setupInfinite builds the providers of the ✨ Infinite category: the Datamuse pool first,
+// then the offline association graphs, so Infinite keeps working without network access.
+func (s *Server) setupInfinite() game.WordProvider {
+	var providers game.FallbackProviders
+
+	if s.Config.DatamuseEnabled {
+		client := game.NewDatamuseClient(s.Config.DatamuseURL, &http.Client{Timeout: s.Config.DatamuseTimeout})
+		client.SetBreaker(game.NewCircuitBreaker(s.Config.DatamuseBreakerThreshold, s.Config.DatamuseBreakerCooldown))
+		client.SetFilter(game.NewDatamuseFilter(s.datamuseFilterConfig()))
+		s.datamuse = game.NewDatamusePool(s.Config.DatamusePoolSize, client)
+		providers = append(providers, s.datamuse)
+	}
+
+	// No association graph is shipped with the server, they come from ASSOCIATIONS_DIR
+	if dir := s.Config.AssociationsDir; dir == "" {
+		log.Println("ASSOCIATIONS_DIR is not set, offline Infinite disabled")
+	} else {
+		offline, err := game.LoadAssociationDir(dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			log.Printf("Association directory %s does not exist, offline Infinite disabled", dir)
+		} else if err != nil {
+			log.Printf("Error loading word associations from %s, offline Infinite disabled: %v", dir, err)
+		} else {
+			log.Printf("Word associations loaded from %s for %v", dir, offline.Languages())
+			s.offline = offline
+			providers = append(providers, offline)
+		}
+	}
+
+	if len(providers) == 0 {
+		log.Println("No Infinite provider, the Infinite category draws local words")
+	}
+	return providers
+}
+
+// datamuseHealth reports the Datamuse pool for /health, nil when Datamuse is disabled.
+func (s *Server) datamuseHealth() *game.DatamuseHealth {
+	if s.datamuse == nil {
+		return nil
+	}
+	h := s.datamuse.Health()
+	return &h
+}
+
+// associationLanguages lists the languages with offline Infinite pairs for /health.
+func (s *Server) associationLanguages() []string {
+	if s.offline == nil {
+		return []string{}
+	}
+	return s.offline.Languages()
+}
+
+// datamuseFilterConfig returns the configured Datamuse filter with the words of the blocklist file.
+// A blocklist that can't be read is logged and ignored rather than keeping the server down.
+func (s *Server) datamuseFilterConfig() game.DatamuseFilterConfig {
+	cfg := s.Config.DatamuseFilter
+	if path := s.Config.DatamuseBlocklist; path != "" {
+		words, err := game.LoadBlocklist(path)
+		if err != nil {
+			log.Printf("Error loading the Datamuse blocklist %s: %v", path, err)
+		} else {
+			cfg.Blocklist = append(cfg.Blocklist, words...)
+		}
+	}
+	return cfg
+}
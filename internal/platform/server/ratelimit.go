@@ -0,0 +1,123 @@
+package server
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"golang.org/x/time/rate"
+)
+
+// RateLimitConfig defines the request budgets enforced by the server.
+// A zero per-minute limit disables that REST limiter.
+type RateLimitConfig struct {
+	LobbyCreatesPerMinute int // POST /api/lobby, per IP
+	JoinsPerMinute        int // Websocket upgrades, per IP
+
+	ChatPerSecond float64 // CHAT_MESSAGE token refill rate, per connection
+	ChatBurst     int
+	GamePerSecond float64 // Every other command (START_GAME, CAST_VOTE, ...), per connection
+	GameBurst     int
+
+	MaxViolations  int           // Rejected commands before the connection is dropped
+	ViolationDecay time.Duration // One violation is forgiven each time this elapses (0 = never)
+}
+
+// DefaultRateLimitConfig returns limits generous enough for normal play.
+func DefaultRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{
+		LobbyCreatesPerMinute: 10,
+		JoinsPerMinute:        30,
+		ChatPerSecond:         1,
+		ChatBurst:             5,
+		GamePerSecond:         5,
+		GameBurst:             10,
+		MaxViolations:         10,
+		ViolationDecay:        time.Minute,
+	}
+}
+
+// ipLimiter returns a fixed window per-IP middleware allowing max requests per minute.
+// Behind a load balancer, the client IP comes from Config.ProxyHeader (see fiberConfig).
+func ipLimiter(max int) fiber.Handler {
+	return limiter.New(limiter.Config{
+		Max:        max,
+		Expiration: time.Minute,
+		Next: func(c *fiber.Ctx) bool {
+			return max <= 0
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": "Too many requests, slow down",
+			})
+		},
+	})
+}
+
+// connLimiter holds the token buckets of a single websocket connection.
+type connLimiter struct {
+	chat       *rate.Limiter
+	game       *rate.Limiter
+	violations int
+	max        int
+	decay      time.Duration
+	decayedAt  time.Time // When the violations were last forgiven
+}
+
+func newConnLimiter(cfg RateLimitConfig) *connLimiter {
+	return &connLimiter{
+		chat:  newBucket(cfg.ChatPerSecond, cfg.ChatBurst),
+		game:  newBucket(cfg.GamePerSecond, cfg.GameBurst),
+		max:   cfg.MaxViolations,
+		decay: cfg.ViolationDecay,
+	}
+}
+
+func newBucket(perSecond float64, burst int) *rate.Limiter {
+	if perSecond <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	return rate.NewLimiter(rate.Limit(perSecond), burst)
+}
+
+// Allow consumes a token for the given action.
+// Chat has its own budget so chatting can't starve game commands and vice versa.
+// Anything else, including messages that are not valid commands, takes from the game budget.
+func (cl *connLimiter) Allow(action string) bool {
+	return cl.allow(action, time.Now())
+}
+
+func (cl *connLimiter) allow(action string, now time.Time) bool {
+	bucket := cl.game
+	if action == "CHAT_MESSAGE" {
+		bucket = cl.chat
+	}
+	if bucket.AllowN(now, 1) {
+		return true
+	}
+	cl.forgive(now)
+	if cl.violations == 0 {
+		cl.decayedAt = now
+	}
+	cl.violations++
+	return false
+}
+
+// forgive drops one violation per decay period elapsed, so a connection that slows down
+// is not disconnected for the bursts of a long session.
+func (cl *connLimiter) forgive(now time.Time) {
+	if cl.decay <= 0 || cl.violations == 0 {
+		return
+	}
+	periods := int(now.Sub(cl.decayedAt) / cl.decay)
+	if periods <= 0 {
+		return
+	}
+	cl.violations = max(cl.violations-periods, 0)
+	cl.decayedAt = cl.decayedAt.Add(time.Duration(periods) * cl.decay)
+}
+
+// Exceeded reports whether the connection broke the limits often enough to be dropped.
+func (cl *connLimiter) Exceeded() bool {
+	return cl.max > 0 && cl.violations >= cl.max
+}
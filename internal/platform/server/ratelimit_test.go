@@ -0,0 +1,93 @@
+package server
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCreateLobbyRateLimit(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.RateLimit.LobbyCreatesPerMinute = 2
+	s := NewServerWithConfig(cfg)
+
+	for i, want := range []int{200, 200, 429} {
+		resp, err := s.App.Test(httptest.NewRequest("POST", "/api/lobby", nil))
+		if err != nil {
+			t.Fatalf("App.Test error: %v", err)
+		}
+		if resp.StatusCode != want {
+			t.Errorf("request %d: expected status %d, got %d", i+1, want, resp.StatusCode)
+		}
+	}
+}
+
+func TestRateLimitBehindProxy(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.RateLimit.LobbyCreatesPerMinute = 1
+	cfg.ProxyHeader = "X-Forwarded-For"
+	s := NewServerWithConfig(cfg)
+
+	for i, tt := range []struct {
+		forwarded string
+		want      int
+	}{{"203.0.113.1", 200}, {"203.0.113.2, 10.0.0.1", 200}, {"203.0.113.1", 429}} {
+		req := httptest.NewRequest("POST", "/api/lobby", nil)
+		req.Header.Set("X-Forwarded-For", tt.forwarded)
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("App.Test error: %v", err)
+		}
+		if resp.StatusCode != tt.want {
+			t.Errorf("request %d from %s: expected status %d, got %d", i+1, tt.forwarded, tt.want, resp.StatusCode)
+		}
+	}
+}
+
+func TestConnLimiter(t *testing.T) {
+	cl := newConnLimiter(RateLimitConfig{
+		ChatPerSecond: 1,
+		ChatBurst:     2,
+		GamePerSecond: 1,
+		GameBurst:     1,
+		MaxViolations: 2,
+	})
+
+	if !cl.Allow("CHAT_MESSAGE") || !cl.Allow("CHAT_MESSAGE") {
+		t.Fatal("chat burst should be allowed")
+	}
+	if cl.Allow("CHAT_MESSAGE") {
+		t.Error("chat over burst should be rejected")
+	}
+
+	// Game commands have their own budget.
+	if !cl.Allow("CAST_VOTE") {
+		t.Error("game command should not be affected by chat usage")
+	}
+	if cl.Exceeded() {
+		t.Error("one violation should not disconnect")
+	}
+	if cl.Allow("CAST_VOTE") {
+		t.Error("game command over burst should be rejected")
+	}
+	if !cl.Exceeded() {
+		t.Error("expected connection to exceed max violations")
+	}
+}
+
+func TestConnLimiterForgivesViolations(t *testing.T) {
+	cl := newConnLimiter(RateLimitConfig{GamePerSecond: 0.001, GameBurst: 1, MaxViolations: 2, ViolationDecay: time.Minute})
+	start := time.Now()
+
+	cl.allow("CAST_VOTE", start)
+	if cl.allow("", start) || cl.Exceeded() {
+		t.Fatal("a malformed message over the budget should count as one violation")
+	}
+	// Long enough for the violation to be forgiven, not for a new token
+	if cl.allow("CAST_VOTE", start.Add(90*time.Second)) || cl.Exceeded() {
+		t.Error("the first violation should have been forgiven")
+	}
+	if cl.allow("CAST_VOTE", start.Add(100*time.Second)); !cl.Exceeded() {
+		t.Error("two violations within a minute should disconnect")
+	}
+}
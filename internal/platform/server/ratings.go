@@ -0,0 +1,21 @@
+package server
+
+import (
+	"impostor/internal/game"
+	"log"
+)
+
+// loadPairRatings loads the votes of the players on the pairs. If the ratings file can't be read,
+// votes are kept in memory so the file is not overwritten until someone looks at it.
+func loadPairRatings(cfg Config) *game.PairRatings {
+	if cfg.RatingsFile != "" {
+		ratings, err := game.NewPairRatings(cfg.Ratings, game.NewFileRatingStore(cfg.RatingsFile))
+		if err == nil {
+			log.Printf("Pair ratings loaded from %s", cfg.RatingsFile)
+			return ratings
+		}
+		log.Printf("Error loading pair ratings from %s, keeping votes in memory: %v", cfg.RatingsFile, err)
+	}
+	ratings, _ := game.NewPairRatings(cfg.Ratings, game.NewMemoryRatingStore())
+	return ratings
+}
@@ -0,0 +1,333 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"impostor/internal/domain"
+	"impostor/internal/game"
+	"impostor/internal/platform/backplane"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Lobbies are owned by the replica that created them. The owner keeps the lobby in its Hub
+// and listens on the lobby inbox; other replicas relay their players through the backplane:
+//
+//	edge replica                      owner replica
+//	player command --> lobby.<id>.in --> handleCommand
+//	websocket <-- lobby.<id>.out.<player> <-- remoteClient.WriteJSON
+const (
+	lobbyLeaseTTL      = time.Minute
+	backplaneTimeout   = 5 * time.Second
+	ownershipKeyPrefix = "lobby-owner."
+)
+
+func ownershipKey(lobbyID string) string {
+	return ownershipKeyPrefix + lobbyID
+}
+
+func inboxChannel(lobbyID string) string {
+	return "lobby." + lobbyID + ".in"
+}
+
+func outboxChannel(lobbyID, playerID string) string {
+	return "lobby." + lobbyID + ".out." + playerID
+}
+
+// envelope is a player event sent by an edge replica to the lobby owner.
+type envelope struct {
+	Kind           string          `json:"kind"` // "join", "command" or "leave"
+	PlayerID       string          `json:"player_id"`
+	PlayerName     string          `json:"player_name,omitempty"`
+	PlayerLanguage string          `json:"player_language,omitempty"` // Only on "join"
+	Payload        json.RawMessage `json:"payload,omitempty"`
+}
+
+// delivery is a message sent by the lobby owner to a single remote player.
+type delivery struct {
+	Close   bool            `json:"close,omitempty"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// remoteClient is the NetworkClient of a player connected to another replica.
+// Its messages go through the outbox, as it is written to under the lobby lock.
+type remoteClient struct {
+	bp       backplane.Backplane
+	outbox   *outbox
+	lobbyID  string
+	playerID string
+}
+
+func (r *remoteClient) WriteJSON(v any) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return r.send(delivery{Payload: payload})
+}
+
+func (r *remoteClient) Close() error {
+	return r.send(delivery{Close: true})
+}
+
+func (r *remoteClient) send(d delivery) error {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	return r.outbox.send(r.bp, outboxChannel(r.lobbyID, r.playerID), data)
+}
+
+// outboxSize is the number of messages to remote players waiting for the backplane
+// before new ones are dropped.
+const outboxSize = 1024
+
+var errOutboxFull = errors.New("backplane outbox full, message dropped")
+
+// outbox publishes the messages of remote players from its own goroutine, in order,
+// so that a slow backplane never holds the lock of the lobby that broadcasts them.
+type outbox struct {
+	queue chan outgoing
+}
+
+type outgoing struct {
+	bp      backplane.Backplane
+	channel string
+	data    []byte
+}
+
+func newOutbox() *outbox {
+	o := &outbox{queue: make(chan outgoing, outboxSize)}
+	go o.run()
+	return o
+}
+
+// send queues a message without waiting for the backplane.
+func (o *outbox) send(bp backplane.Backplane, channel string, data []byte) error {
+	select {
+	case o.queue <- outgoing{bp: bp, channel: channel, data: data}:
+		return nil
+	default:
+		return errOutboxFull
+	}
+}
+
+func (o *outbox) run() {
+	for m := range o.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), backplaneTimeout)
+		if err := m.bp.Publish(ctx, m.channel, m.data); err != nil {
+			log.Printf("Error relaying to %s: %v", m.channel, err)
+		}
+		cancel()
+	}
+}
+
+// hostedLobbies tracks the inbox subscriptions of the lobbies owned by this replica.
+type hostedLobbies struct {
+	mu     sync.Mutex
+	cancel map[string]func()
+}
+
+// hostLobby claims ownership of a freshly created lobby and starts accepting remote players.
+func (s *Server) hostLobby(lobby *game.Lobby) error {
+	ctx, cancel := context.WithTimeout(context.Background(), backplaneTimeout)
+	defer cancel()
+
+	if _, err := s.Backplane.Claim(ctx, ownershipKey(lobby.ID), s.Config.ReplicaID, lobbyLeaseTTL); err != nil {
+		return err
+	}
+
+	unsubscribe, err := s.Backplane.Subscribe(ctx, inboxChannel(lobby.ID), func(payload []byte) {
+		var env envelope
+		if err := json.Unmarshal(payload, &env); err != nil {
+			log.Printf("Invalid envelope for lobby %s: %v", lobby.ID, err)
+			return
+		}
+		s.handleEnvelope(lobby, env)
+	})
+	if err != nil {
+		s.Backplane.Release(ctx, ownershipKey(lobby.ID), s.Config.ReplicaID)
+		return err
+	}
+
+	s.hosted.mu.Lock()
+	s.hosted.cancel[lobby.ID] = unsubscribe
+	s.hosted.mu.Unlock()
+	return nil
+}
+
+// releaseLobby stops accepting remote players and gives up ownership.
+// Registered as the Hub remove hook, so it runs however the lobby goes away.
+func (s *Server) releaseLobby(id string) {
+	s.hosted.mu.Lock()
+	unsubscribe, ok := s.hosted.cancel[id]
+	delete(s.hosted.cancel, id)
+	s.hosted.mu.Unlock()
+
+	if !ok {
+		return
+	}
+	unsubscribe()
+
+	ctx, cancel := context.WithTimeout(context.Background(), backplaneTimeout)
+	defer cancel()
+	if err := s.Backplane.Release(ctx, ownershipKey(id), s.Config.ReplicaID); err != nil {
+		log.Printf("Error releasing lobby %s: %v", id, err)
+	}
+}
+
+// refreshLeases keeps the ownership of hosted lobbies alive until ctx is cancelled.
+// If this replica dies, its leases expire and the lobbies are no longer advertised.
+func (s *Server) refreshLeases(ctx context.Context) {
+	ticker := time.NewTicker(lobbyLeaseTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.refreshHostedLeases(ctx)
+		}
+	}
+}
+
+// refreshHostedLeases refreshes the lease of every hosted lobby once. A lobby whose lease was
+// taken by another replica is closed here, so that only one replica ever acts as its authority;
+// its players are told to reconnect.
+func (s *Server) refreshHostedLeases(ctx context.Context) {
+	s.hosted.mu.Lock()
+	ids := make([]string, 0, len(s.hosted.cancel))
+	for id := range s.hosted.cancel {
+		ids = append(ids, id)
+	}
+	s.hosted.mu.Unlock()
+
+	for _, id := range ids {
+		claimCtx, cancel := context.WithTimeout(ctx, backplaneTimeout)
+		owner, err := s.Backplane.Claim(claimCtx, ownershipKey(id), s.Config.ReplicaID, lobbyLeaseTTL)
+		cancel()
+		if err != nil {
+			log.Printf("Error refreshing lease of lobby %s: %v", id, err)
+		} else if owner != s.Config.ReplicaID {
+			log.Printf("Lost ownership of lobby %s to %s, closing it", id, owner)
+			// The remove hook stops hosting it; its Release leaves the lease of the new owner alone
+			s.Hub.CloseLobby(id, "Lobby moved to another server, please reconnect")
+		}
+	}
+}
+
+// isHostedElsewhere reports whether another replica owns the lobby.
+func (s *Server) isHostedElsewhere(lobbyID string) bool {
+	return s.remoteOwner(lobbyID) != ""
+}
+
+// remoteOwner returns the replica owning the lobby, or "" if it is unclaimed or owned by this replica.
+func (s *Server) remoteOwner(lobbyID string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), backplaneTimeout)
+	defer cancel()
+
+	owner, err := s.Backplane.Owner(ctx, ownershipKey(lobbyID))
+	if err != nil {
+		log.Printf("Error looking up owner of lobby %s: %v", lobbyID, err)
+		return ""
+	}
+	if owner == s.Config.ReplicaID {
+		return ""
+	}
+	return owner
+}
+
+// lobbyNotFound replies to an HTTP request for a lobby missing from the Hub. REST endpoints only
+// act on the lobbies of this replica: for a lobby owned by another one, the reply is
+// 421 Misdirected Request with the owner, so that the caller can retry there.
+func (s *Server) lobbyNotFound(c *fiber.Ctx, lobbyID string) error {
+	if owner := s.remoteOwner(lobbyID); owner != "" {
+		return c.Status(fiber.StatusMisdirectedRequest).JSON(fiber.Map{
+			"error":   "Lobby is hosted by another replica",
+			"replica": owner,
+		})
+	}
+	return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+		"error": "Lobby not found",
+	})
+}
+
+// handleEnvelope applies an event from a remote player to a lobby owned by this replica.
+func (s *Server) handleEnvelope(lobby *game.Lobby, env envelope) {
+	switch env.Kind {
+	case "join":
+		p := &domain.Player{
+			ID:       env.PlayerID,
+			Name:     env.PlayerName,
+			Language: env.PlayerLanguage,
+			IsAlive:  true,
+		}
+		client := &remoteClient{bp: s.Backplane, outbox: s.outbox, lobbyID: lobby.ID, playerID: env.PlayerID}
+		if err := s.joinLobby(lobby, p, client); err != nil {
+			log.Printf("Remote player %s rejected from lobby %s: %v", env.PlayerName, lobby.ID, err)
+			client.Close()
+		}
+	case "command":
+		s.handleCommand(s.ctx, lobby, env.PlayerID, env.PlayerName, env.Payload)
+	case "leave":
+		// Rejected joins also produce a leave, ignore those.
+		if lobby.HasPlayer(env.PlayerID) {
+			s.leaveLobby(lobby, env.PlayerID, nil)
+		}
+	default:
+		log.Printf("Unknown envelope kind %q for lobby %s", env.Kind, lobby.ID)
+	}
+}
+
+// serveRemotePlayer relays a local websocket to a lobby owned by another replica.
+func (s *Server) serveRemotePlayer(client *wsClient, lobbyID, playerID, playerName, language string) {
+	ctx := context.Background()
+
+	unsubscribe, err := s.Backplane.Subscribe(ctx, outboxChannel(lobbyID, playerID), func(payload []byte) {
+		var d delivery
+		if err := json.Unmarshal(payload, &d); err != nil {
+			log.Printf("Invalid delivery for player %s: %v", playerID, err)
+			return
+		}
+		if d.Close {
+			client.Close()
+			return
+		}
+		if err := client.WriteJSON(d.Payload); err != nil {
+			log.Printf("Error sending to player %s: %v", playerID, err)
+		}
+	})
+	if err != nil {
+		log.Printf("Error subscribing player %s to lobby %s: %v", playerID, lobbyID, err)
+		client.Close()
+		return
+	}
+	defer unsubscribe()
+
+	publish := func(env envelope) {
+		env.PlayerID = playerID
+		env.PlayerName = playerName
+		data, err := json.Marshal(env)
+		if err != nil {
+			return
+		}
+		pubCtx, cancel := context.WithTimeout(ctx, backplaneTimeout)
+		defer cancel()
+		if err := s.Backplane.Publish(pubCtx, inboxChannel(lobbyID), data); err != nil {
+			log.Printf("Error relaying to lobby %s: %v", lobbyID, err)
+		}
+	}
+
+	log.Printf("Relaying player %s to lobby %s on another replica", playerName, lobbyID)
+	publish(envelope{Kind: "join", PlayerLanguage: language})
+	defer publish(envelope{Kind: "leave"})
+
+	s.readLoop(client, playerName, func(msg []byte) {
+		publish(envelope{Kind: "command", Payload: msg})
+	})
+}
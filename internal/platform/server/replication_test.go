@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"impostor/internal/domain"
+	"impostor/internal/platform/backplane"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRemotePlayerRelay(t *testing.T) {
+	ctx := context.Background()
+	bp := backplane.NewMemory()
+
+	owner := NewServerWithConfig(DefaultConfig())
+	owner.Backplane = bp
+	edge := NewServerWithConfig(DefaultConfig())
+	edge.Backplane = bp
+
+	// The owner creates the lobby through the API.
+	resp, err := owner.App.Test(httptest.NewRequest("POST", "/api/lobby", nil))
+	if err != nil {
+		t.Fatalf("App.Test error: %v", err)
+	}
+	var created map[string]string
+	json.NewDecoder(resp.Body).Decode(&created)
+	lobbyID := created["lobby_id"]
+
+	if !edge.isHostedElsewhere(lobbyID) {
+		t.Fatal("edge replica should see the lobby as hosted by the owner")
+	}
+	if owner.isHostedElsewhere(lobbyID) {
+		t.Fatal("owner replica should not relay its own lobby")
+	}
+
+	// Listen to what the owner sends to the remote player.
+	deliveries := make(chan delivery, 10)
+	cancel, _ := bp.Subscribe(ctx, outboxChannel(lobbyID, "p1"), func(payload []byte) {
+		var d delivery
+		json.Unmarshal(payload, &d)
+		deliveries <- d
+	})
+	defer cancel()
+
+	publish := func(env envelope) {
+		data, _ := json.Marshal(env)
+		bp.Publish(ctx, inboxChannel(lobbyID), data)
+	}
+	publish(envelope{Kind: "join", PlayerID: "p1", PlayerName: "Ana"})
+
+	// The remote player gets their token, then the player list
+	for _, want := range []string{"SESSION", "PLAYER_LIST"} {
+		select {
+		case d := <-deliveries:
+			var msg map[string]any
+			json.Unmarshal(d.Payload, &msg)
+			if msg["type"] != want {
+				t.Errorf("expected %s, got %v", want, msg)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for %s", want)
+		}
+	}
+
+	// Once the only player leaves, the owner deletes the lobby and releases it.
+	publish(envelope{Kind: "leave", PlayerID: "p1"})
+	deadline := time.Now().Add(2 * time.Second)
+	for edge.isHostedElsewhere(lobbyID) {
+		if time.Now().After(deadline) {
+			t.Fatal("lobby ownership was not released")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, ok := owner.Hub.GetLobby(lobbyID); ok {
+		t.Error("empty lobby should have been deleted")
+	}
+}
+
+func TestLostLeaseClosesLobby(t *testing.T) {
+	ctx := context.Background()
+	s := NewServerWithConfig(DefaultConfig())
+	lobby, _ := s.Hub.CreateLobby("lobby-1", nil)
+	if err := s.hostLobby(lobby); err != nil {
+		t.Fatal(err)
+	}
+	lobby.AddPlayerSafe(&domain.Player{ID: "p1", Name: "Ana"})
+	client := &recordingClient{}
+	lobby.RegisterClient("p1", client)
+
+	// Still ours: nothing changes
+	s.refreshHostedLeases(ctx)
+	if _, ok := s.Hub.GetLobby("lobby-1"); !ok {
+		t.Fatal("lobby closed while its lease is held")
+	}
+
+	// The lease expired and another replica took it
+	s.Backplane.Release(ctx, ownershipKey("lobby-1"), s.Config.ReplicaID)
+	s.Backplane.Claim(ctx, ownershipKey("lobby-1"), "other-replica", time.Minute)
+	s.refreshHostedLeases(ctx)
+
+	if _, ok := s.Hub.GetLobby("lobby-1"); ok {
+		t.Error("lobby still hosted after losing its lease")
+	}
+	if !client.closed {
+		t.Error("players of the lost lobby were not disconnected")
+	}
+	if owner, _ := s.Backplane.Owner(ctx, ownershipKey("lobby-1")); owner != "other-replica" {
+		t.Errorf("Owner() = %q, the new owner lost its lease", owner)
+	}
+	req := httptest.NewRequest("POST", "/api/lobby/lobby-1/categories", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := s.App.Test(req)
+	if resp.StatusCode != fiber.StatusMisdirectedRequest {
+		t.Errorf("upload to a lobby of another replica: status %d, want 421", resp.StatusCode)
+	}
+}
+
+// slowBackplane blocks every publish until released.
+type slowBackplane struct {
+	backplane.Backplane
+	release chan struct{}
+}
+
+func (b *slowBackplane) Publish(ctx context.Context, channel string, payload []byte) error {
+	<-b.release
+	return b.Backplane.Publish(ctx, channel, payload)
+}
+
+func TestRemoteClientDoesNotWaitForBackplane(t *testing.T) {
+	bp := &slowBackplane{Backplane: backplane.NewMemory(), release: make(chan struct{})}
+	defer close(bp.release)
+	client := &remoteClient{bp: bp, outbox: newOutbox(), lobbyID: "lobby-1", playerID: "p1"}
+
+	done := make(chan struct{})
+	go func() {
+		for range 3 {
+			client.WriteJSON(map[string]string{"type": "PLAYER_LIST"})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("WriteJSON() waited for the backplane")
+	}
+}
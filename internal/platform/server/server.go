@@ -1,7 +1,9 @@
 package server
 
 import (
+	"context"
 	"impostor/internal/game"
+	"impostor/internal/platform/backplane"
 	"log"
 
 	"github.com/gofiber/fiber/v2"
@@ -11,43 +13,126 @@ import (
 
 // Server contains the Fiber instance and the Game Hub.
 type Server struct {
-	App *fiber.App
-	Hub *game.Hub
+	App        *fiber.App
+	Hub        *game.Hub
+	Backplane  backplane.Backplane
+	Dictionary *game.DictionaryManager
+	Config     Config
+
+	ctx         context.Context // Background work started by Run stops when it is done, see Shutdown
+	stop        context.CancelFunc
+	hosted      hostedLobbies             // Lobbies owned by this replica
+	outbox      *outbox                   // Messages to players relayed from other replicas
+	dictWatcher *game.DictionaryWatcher   // nil when using the built-in dictionary
+	datamuse    *game.DatamusePool        // Infinite pairs, prefetched by Run; nil when disabled
+	offline     *game.AssociationProvider // Offline Infinite pairs, nil without ASSOCIATIONS_DIR
+	ratings     *game.PairRatings         // Votes on the pairs, saved by Run
 }
 
-// NewServer initializes the web server and its dependencies.
+// NewServer initializes the web server using the configuration from the environment.
 func NewServer() *Server {
-	app := fiber.New(fiber.Config{
-		AppName: "The Impostor Agent",
-	})
+	return NewServerWithConfig(LoadConfig())
+}
+
+// NewServerWithConfig initializes the web server and its dependencies.
+func NewServerWithConfig(cfg Config) *Server {
+	app := fiber.New(fiberConfig(cfg))
 
 	// Middleware
 	app.Use(logger.New())
 	app.Use(cors.New())
 
 	// Initialize Hub
+	if err := cfg.Limits.Validate(); err != nil {
+		log.Fatalf("Invalid LOBBY_MAX_PLAYERS / LOBBY_MAX_PLAYERS_CEILING: %v", err)
+	}
 	hub := game.NewHub()
+	hub.SetLimits(cfg.Limits)
 
+	// Initialize Backplane (single replica unless Redis is configured)
+	var bp backplane.Backplane = backplane.NewMemory()
+	if cfg.RedisAddr != "" {
+		redisBP, err := backplane.NewRedis(context.Background(), cfg.RedisAddr, cfg.RedisPrefix)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Using Redis backplane at %s as replica %s", cfg.RedisAddr, cfg.ReplicaID)
+		bp = redisBP
+	}
+
+	ctx, stop := context.WithCancel(context.Background())
 	s := &Server{
-		App: app,
-		Hub: hub,
+		ctx:       ctx,
+		stop:      stop,
+		App:       app,
+		Hub:       hub,
+		Backplane: bp,
+		Config:    cfg,
+		hosted:    hostedLobbies{cancel: make(map[string]func())},
+		outbox:    newOutbox(),
+	}
+	hub.SetRemoveHook(s.releaseLobby)
+	hub.SetInfiniteProvider(s.setupInfinite())
+	hub.SetContentFilter(s.loadContentFilter(), cfg.ContentFilter)
+
+	// Load Dictionary (the built-in one stays in use if the directory is invalid)
+	// Edits made through the API are written back to the directory, or kept in memory without one.
+	var store game.DictionaryStore = game.NewMemoryDictionaryStore()
+	if cfg.DictionaryDir != "" {
+		store = game.NewFileDictionaryStore(cfg.DictionaryDir)
+		s.dictWatcher = game.NewDictionaryWatcher(cfg.DictionaryDir, cfg.DictionaryReloadInterval)
+		if err := s.dictWatcher.Load(); err != nil {
+			log.Printf("Error loading dictionary from %s, using built-in: %v", cfg.DictionaryDir, err)
+		} else {
+			log.Printf("Dictionary loaded from %s", cfg.DictionaryDir)
+		}
 	}
+	s.Dictionary = game.NewDictionaryManager(store)
+	s.ratings = loadPairRatings(cfg)
+	game.SetPairRatings(s.ratings)
 
 	s.setupRoutes()
 	return s
 }
 
+// fiberConfig returns the Fiber settings, with the client IP taken from the proxy header when configured.
+func fiberConfig(cfg Config) fiber.Config {
+	fc := fiber.Config{
+		AppName: "The Impostor Agent",
+	}
+	if cfg.ProxyHeader != "" {
+		fc.ProxyHeader = cfg.ProxyHeader
+		fc.EnableIPValidation = true // First valid IP of X-Forwarded-For lists
+		if len(cfg.TrustedProxies) > 0 {
+			fc.EnableTrustedProxyCheck = true
+			fc.TrustedProxies = cfg.TrustedProxies
+		} else {
+			log.Printf("PROXY_HEADER is set without TRUSTED_PROXIES: any client can choose the IP it is rate limited as")
+		}
+	}
+	return fc
+}
+
 func (s *Server) setupRoutes() {
 	// Health check
 	s.App.Get("/health", func(c *fiber.Ctx) error {
-		return c.JSON(fiber.Map{"status": "ok"})
+		return c.JSON(fiber.Map{
+			"status":       "ok",
+			"reaper":       s.Hub.ReaperStats(),
+			"datamuse":     s.datamuseHealth(),
+			"associations": s.associationLanguages(),
+		})
 	})
 
 	// REST API Routes
-	s.App.Post("/api/lobby", s.createLobbyHandler)
+	s.App.Post("/api/lobby", ipLimiter(s.Config.RateLimit.LobbyCreatesPerMinute), s.createLobbyHandler)
+	s.App.Post("/api/lobby/:id/categories", s.uploadCategoryHandler)
+	s.App.Get("/api/languages", s.getLanguagesHandler)
 	s.App.Get("/api/categories", s.getCategoriesHandler)
 	s.App.Get("/api/word", s.getRandomWordHandler)
 
+	s.setupAdminRoutes()
+
 	s.setupWebsocketRoutes()
 
 	// Serve Static Files (Frontend)
@@ -61,8 +146,31 @@ func (s *Server) setupRoutes() {
 	})
 }
 
-// Run starts the server on the specified port.
+// Run starts the server on the specified port, until Shutdown is called.
 func (s *Server) Run(port string) {
+	s.Hub.StartReaper(s.ctx, s.Config.Reaper)
+	go s.refreshLeases(s.ctx)
+	if s.datamuse != nil {
+		s.datamuse.Start(s.ctx)
+	}
+	if s.dictWatcher != nil {
+		go s.dictWatcher.Watch(s.ctx)
+	}
+	go s.ratings.Run(s.ctx)
+
 	log.Printf("Server listening on %s", port)
-	log.Fatal(s.App.Listen(port))
+	if err := s.App.Listen(port); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Shutdown stops the background work started by Run, including the Datamuse refills,
+// and closes the listener once the requests in progress are served. Pending votes are saved.
+func (s *Server) Shutdown() error {
+	s.stop()
+	err := s.App.Shutdown()
+	if saveErr := s.ratings.Flush(); saveErr != nil {
+		log.Printf("Could not save the pair ratings: %v", saveErr)
+	}
+	return err
 }
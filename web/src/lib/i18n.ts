@@ -31,6 +31,13 @@ const translations: Translations = {
   'lobby.easy': { en: 'EASY', es: 'FÁCIL' },
   'lobby.trapDetected': { en: '>> TRAP WORD DETECTED FOR IMPOSTOR', es: '>> PALABRA TRAMPA DETECTADA PARA IMPOSTOR' },
   'lobby.blindMode': { en: '>> BLIND MODE ACTIVE', es: '>> MODO CIEGO ACTIVO' },
+  'lobby.hintInstead': { en: 'Give a hint instead of a trap word', es: 'Dar una pista en lugar de palabra trampa' },
+  'lobby.adaptive': { en: 'Adapt the words to who keeps winning', es: 'Adaptar las palabras a quien va ganando' },
+  'lobby.contentFilter': { en: 'Filter names and chat', es: 'Filtrar nombres y chat' },
+  'lobby.filterOff': { en: 'Off', es: 'Desactivado' },
+  'lobby.filterMask': { en: 'Hide blocked words', es: 'Ocultar palabras bloqueadas' },
+  'lobby.filterReject': { en: 'Refuse blocked words', es: 'Rechazar palabras bloqueadas' },
+  'lobby.hintDetected': { en: '>> CATEGORY AND HINT FOR IMPOSTOR', es: '>> CATEGORÍA Y PISTA PARA IMPOSTOR' },
   'lobby.systemsReady': { en: 'All systems nominal. Awaiting command.', es: 'Todos los sistemas nominales. Esperando comando.' },
   'lobby.initiateLaunch': { en: 'INITIATE LAUNCH', es: 'INICIAR LANZAMIENTO' },
   'lobby.minPlayers': { en: 'Minimum 3 operatives required', es: 'Se requieren mínimo 3 operativos' },
@@ -46,6 +53,11 @@ const translations: Translations = {
   'game.impostor': { en: 'IMPOSTOR', es: 'IMPOSTOR' },
   'game.eliminated': { en: 'was eliminated', es: 'fue eliminado' },
   'game.wasThe': { en: 'They were the', es: 'Era' },
+  'game.secretWordWas': { en: 'The secret word was', es: 'La palabra secreta era' },
+  'game.trapWordWas': { en: 'Trap word', es: 'Palabra trampa' },
+  'game.ratePair': { en: 'Good pair?', es: '¿Buena pareja?' },
+  'game.rateUp': { en: 'Good pair', es: 'Buena pareja' },
+  'game.rateDown': { en: 'Bad pair', es: 'Mala pareja' },
   'game.playAgain': { en: 'PLAY AGAIN (KEEP LOBBY)', es: 'JUGAR DE NUEVO (MANTENER LOBBY)' },
 
   // Voting Panel
@@ -70,6 +82,8 @@ const translations: Translations = {
   'card.secretWord': { en: 'SECRET WORD', es: 'PALABRA SECRETA' },
   'card.impostor': { en: 'Impostor', es: 'Impostor' },
   'card.civilian': { en: 'Civilian', es: 'Civil' },
+  'card.category': { en: 'Category', es: 'Categoría' },
+  'card.hint': { en: 'Hint', es: 'Pista' },
 
   // Chat Component
   'chat.title': { en: 'Mission Comms', es: 'Comunicaciones de Misión' },
@@ -77,6 +91,10 @@ const translations: Translations = {
   'chat.typeMessage': { en: 'Type a message...', es: 'Escribe un mensaje...' },
   'chat.send': { en: 'SEND', es: 'ENVIAR' },
 
+  // Server errors
+  'error.title': { en: 'Transmission refused', es: 'Transmisión rechazada' },
+  'error.dismiss': { en: 'Dismiss', es: 'Cerrar' },
+
   // Offline Mode
   'offline.passTheDevice': { en: 'Pass the Device', es: 'Pasa el Dispositivo' },
   'offline.player': { en: 'Player', es: 'Jugador' },
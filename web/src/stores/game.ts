@@ -1,4 +1,5 @@
-import { writable } from 'svelte/store';
+import { get, writable } from 'svelte/store';
+import { language } from './language';
 
 // Define the shape of our frontend state (mirroring Go structs)
 export interface GameState {
@@ -10,14 +11,22 @@ export interface GameState {
     isLeader: boolean;
     role?: 'CIVILIAN' | 'IMPOSTOR';
     word?: string;
+    category?: string; // Easy hint mode, impostor only
+    hint?: string;
   };
   players: Array<{ id: string; name: string; is_leader?: boolean; role?: string }>;
   messages: Array<{ from: string; text: string }>;
   winner?: string;
   kicked?: string;
   role_was?: string;
+  reveal?: { real: string; trap: string; description?: string };
+  rated?: 'up' | 'down'; // This player's vote on the revealed pair
+  contentFilter?: ContentFilter; // Screening of names and chat, set by the leader
+  error?: { code: string; message: string }; // Last ERROR event, until dismissed
 }
 
+export type ContentFilter = 'off' | 'mask' | 'reject';
+
 const initialState: GameState = {
   status: 'CONNECTING',
   lobbyId: '',
@@ -41,7 +50,8 @@ export const connect = (lobbyId: string, playerName: string) => {
   // Connect to Backend (dynamically determine host)
   const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
   const host = window.location.host;
-  socket = new WebSocket(`${protocol}//${host}/ws/${lobbyId}?playerId=${playerId}&playerName=${playerName}`);
+  // Each player sees the words in their own UI language
+  socket = new WebSocket(`${protocol}//${host}/ws/${lobbyId}?playerId=${playerId}&playerName=${playerName}&lang=${get(language)}`);
 
   socket.onopen = () => {
     console.log("Connected to WS");
@@ -93,7 +103,7 @@ export const connect = (lobbyId: string, playerName: string) => {
       if (data.role) {
         game.update(g => ({
           ...g,
-          me: { ...g.me, role: data.role, word: data.displayed_word }
+          me: { ...g.me, role: data.role, word: data.displayed_word, category: data.category, hint: data.hint }
         }));
       }
       // Add other handlers (player list updates etc)
@@ -109,11 +119,24 @@ export const connect = (lobbyId: string, playerName: string) => {
           winner: data.winner,
           kicked: data.kicked,
           role_was: data.role_was,
+          reveal: data.real ? { real: data.real, trap: data.trap, description: data.description } : undefined,
+          rated: undefined,
           players: data.reveal || g.players // Update players with roles if provided
         }));
       }
+      if (data.type === 'LOBBY_CONFIG' && data.content_filter) {
+        game.update(g => ({ ...g, contentFilter: data.content_filter }));
+      }
+      if (data.type === 'ERROR') {
+        game.update(g => ({ ...g, error: { code: data.code, message: data.message } }));
+        clearTimeout(errorTimer);
+        errorTimer = setTimeout(dismissError, 5000);
+      }
+      if (data.type === 'PAIR_RATED') {
+        game.update(g => ({ ...g, rated: data.rating }));
+      }
       if (data.type === 'GAME_RESET' || (data.status === 'WAITING' && !data.type)) {
-        game.update(g => ({ ...g, status: 'WAITING', winner: undefined, kicked: undefined, role_was: undefined }));
+        game.update(g => ({ ...g, status: 'WAITING', winner: undefined, kicked: undefined, role_was: undefined, reveal: undefined, rated: undefined }));
       }
     } catch (e) {
       console.error("Parse error", e);
@@ -126,6 +149,13 @@ export const connect = (lobbyId: string, playerName: string) => {
   };
 };
 
+let errorTimer: ReturnType<typeof setTimeout> | undefined;
+
+export const dismissError = () => {
+  clearTimeout(errorTimer);
+  updateGame({ error: undefined });
+};
+
 export const sendAction = (action: string, payload?: any) => {
   if (socket && socket.readyState === WebSocket.OPEN) {
     socket.send(JSON.stringify({ action, ...payload }));
@@ -63,11 +63,14 @@ function createOfflineStore() {
 
     // Setup & Start
     startGame: async (config: OfflineConfig) => {
-      // Fetch word from API
+      // Fetch word from API, skipping the words already played in this category
       try {
-        const res = await fetch(`/api/word?category=${encodeURIComponent(config.category)}&lang=${config.lang}`);
+        const played = loadPlayed(config);
+        const res = await fetch(`/api/word?category=${encodeURIComponent(config.category)}&lang=${config.lang}&exclude=${encodeURIComponent(played.join(','))}`);
         if (!res.ok) throw new Error("Failed to fetch word");
-        const wordPair = await res.json();
+        const { real, trap, reshuffled } = await res.json();
+        const wordPair = { real, trap };
+        savePlayed(config, reshuffled ? [real] : [...played, real]);
 
         const impostorIdx = Math.floor(Math.random() * config.playerCount);
         const startingPlayerId = Math.floor(Math.random() * config.playerCount) + 1;
@@ -164,4 +167,21 @@ function saveState(state: OfflineGameState) {
   setCookie('impostor_offline_game', JSON.stringify(state));
 }
 
+// Words already played per language and category, so the server doesn't repeat them
+function playedKey(config: OfflineConfig): string {
+  return `impostor_offline_played:${config.lang}:${config.category}`;
+}
+
+function loadPlayed(config: OfflineConfig): string[] {
+  try {
+    return JSON.parse(localStorage.getItem(playedKey(config)) ?? '[]');
+  } catch {
+    return [];
+  }
+}
+
+function savePlayed(config: OfflineConfig, played: string[]) {
+  localStorage.setItem(playedKey(config), JSON.stringify(played));
+}
+
 export const offline = createOfflineStore();